@@ -0,0 +1,134 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2"
+)
+
+func init() {
+	caddy.RegisterModule(adminAPI{})
+}
+
+// adminAPI is a module that provides the /i18n/ endpoints for the Caddy
+// admin API. It reports on the dictionaries loaded by all provisioned
+// i18n template extensions.
+type adminAPI struct{}
+
+// CaddyModule returns the Caddy module information.
+func (adminAPI) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "admin.api.i18n",
+		New: func() caddy.Module { return new(adminAPI) },
+	}
+}
+
+// Routes returns the routes for the /i18n/ admin endpoints.
+func (a adminAPI) Routes() []caddy.AdminRoute {
+	return []caddy.AdminRoute{
+		{
+			Pattern: "/i18n/coverage",
+			Handler: caddy.AdminHandlerFunc(a.handleCoverage),
+		},
+		{
+			Pattern: "/i18n/missing",
+			Handler: caddy.AdminHandlerFunc(a.handleMissing),
+		},
+	}
+}
+
+// handleCoverage responds with a coverage report for every loaded dictionary.
+//
+// Query parameters:
+//   - lang: Comma-separated languages to check instead of the configured
+//     required_languages (may be repeated)
+//   - dict_file: Only report on the given dictionary file
+func (adminAPI) handleCoverage(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return caddy.APIError{
+			HTTPStatus: http.StatusMethodNotAllowed,
+			Err:        fmt.Errorf("method not allowed"),
+		}
+	}
+
+	var langs []string
+	for _, v := range r.URL.Query()["lang"] {
+		for _, lang := range strings.Split(v, ",") {
+			if lang = strings.TrimSpace(lang); lang != "" {
+				langs = append(langs, lang)
+			}
+		}
+	}
+	dictFile := r.URL.Query().Get("dict_file")
+
+	reports := []CoverageReport{}
+	for _, i := range instances.byDictFile() {
+		if dictFile != "" && absPath(i.DictFile) != absPath(dictFile) {
+			continue
+		}
+		reports = append(reports, i.coverageReport(langs))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(reports)
+}
+
+// handleMissing responds with the translation misses collected at runtime
+// for every loaded dictionary, merged across all instances loading the same
+// file. A DELETE request clears the collected misses of all those instances,
+// e.g. after the dictionary has been updated.
+//
+// Query parameters:
+//   - dict_file: Only report on (or clear) the given dictionary file
+func (adminAPI) handleMissing(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		return caddy.APIError{
+			HTTPStatus: http.StatusMethodNotAllowed,
+			Err:        fmt.Errorf("method not allowed"),
+		}
+	}
+
+	dictFile := r.URL.Query().Get("dict_file")
+
+	reports := []MissingReport{}
+	for _, group := range instances.groupedByDictFile() {
+		if dictFile != "" && absPath(group[0].DictFile) != absPath(dictFile) {
+			continue
+		}
+		if r.Method == http.MethodDelete {
+			for _, i := range group {
+				i.missing.reset()
+			}
+			continue
+		}
+		reports = append(reports, mergeMissingReports(group))
+	}
+
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(reports)
+}
+
+// Interface guard ensures that adminAPI implements caddy.AdminRouter.
+var _ caddy.AdminRouter = (*adminAPI)(nil)
@@ -0,0 +1,226 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+)
+
+// Locale URL patterns.
+const (
+	// localeURLPath puts the language in the first path segment, e.g. /de/about.
+	localeURLPath = "path"
+
+	// localeURLSubdomain puts the language in the first host label, e.g. de.example.com.
+	localeURLSubdomain = "subdomain"
+
+	// localeURLQuery puts the language in a query parameter, e.g. /about?lang=de.
+	localeURLQuery = "query"
+)
+
+// LocaleURLConfig describes how the URL of a page differs between languages.
+// It is used to generate the hreflang alternate links.
+type LocaleURLConfig struct {
+	// Pattern is "path", "subdomain" or "query". Defaults to "path".
+	Pattern string `json:"pattern,omitempty"`
+
+	// QueryParam is the query parameter used by the "query" pattern.
+	// Defaults to "lang".
+	QueryParam string `json:"query_param,omitempty"`
+
+	// XDefault is the language whose URL is used for the x-default
+	// alternate. If empty, x-default points to the URL without language,
+	// which is expected to negotiate the language, e.g. by redirecting.
+	XDefault string `json:"x_default,omitempty"`
+
+	// BaseURL is the scheme and host of the generated URLs without
+	// language, e.g. "https://example.com". If empty, they are taken from
+	// the request, including its client-controlled Host header.
+	BaseURL string `json:"base_url,omitempty"`
+
+	// KeepQuery carries the query string of the request over to the
+	// generated URLs. By default, it is dropped, so that tracking or debug
+	// parameters don't end up in the alternate links; only the language
+	// parameter of the "query" pattern is set.
+	KeepQuery bool `json:"keep_query,omitempty"`
+}
+
+// validate checks the configured pattern and base URL.
+func (c *LocaleURLConfig) validate() error {
+	switch c.Pattern {
+	case "", localeURLPath, localeURLSubdomain, localeURLQuery:
+	default:
+		return fmt.Errorf("invalid locale_urls pattern %q: must be %q, %q or %q", c.Pattern, localeURLPath, localeURLSubdomain, localeURLQuery)
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("invalid locale_urls base_url %q: must be an http or https URL without path, e.g. https://example.com", c.BaseURL)
+		}
+	}
+	return nil
+}
+
+// baseURL returns the configured base URL, or nil if it is not set.
+func (c *LocaleURLConfig) baseURL() *url.URL {
+	if c == nil || c.BaseURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// keepQuery reports whether the query string of the request is carried over.
+func (c *LocaleURLConfig) keepQuery() bool {
+	return c != nil && c.KeepQuery
+}
+
+// pattern returns the configured pattern, or "path" if c is nil.
+func (c *LocaleURLConfig) pattern() string {
+	if c == nil || c.Pattern == "" {
+		return localeURLPath
+	}
+	return c.Pattern
+}
+
+// queryParam returns the query parameter of the "query" pattern.
+func (c *LocaleURLConfig) queryParam() string {
+	if c == nil || c.QueryParam == "" {
+		return "lang"
+	}
+	return c.QueryParam
+}
+
+// xDefault returns the language of the x-default alternate, or "" for the
+// URL without language.
+func (c *LocaleURLConfig) xDefault() string {
+	if c == nil {
+		return ""
+	}
+	return c.XDefault
+}
+
+// supportedLanguages returns the languages of the loaded dictionary, sorted.
+func (i *I18n) supportedLanguages() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	langs := make([]string, 0, len(i.langCounts))
+	for lang := range i.langCounts {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// neutralURL returns the absolute URL of the page requested by r with the
+// language removed according to the configured pattern. The URL is taken
+// from the original request, before any rewrites such as try_files or
+// strip_prefix of i18n_negotiate. Scheme and host come from the configured
+// base URL if set, and from the request otherwise. The query string is
+// dropped unless KeepQuery is set.
+func (i *I18n) neutralURL(r *http.Request) *url.URL {
+	if orig, ok := r.Context().Value(caddyhttp.OriginalRequestCtxKey).(http.Request); ok {
+		r = &orig
+	}
+
+	u := &url.URL{
+		Scheme: "http",
+		Host:   r.Host,
+		Path:   r.URL.Path,
+	}
+	if i.LocaleURLs.keepQuery() {
+		u.RawQuery = r.URL.RawQuery
+	}
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	base := i.LocaleURLs.baseURL()
+	if base != nil {
+		u.Scheme = base.Scheme
+		u.Host = base.Host
+	}
+
+	switch i.LocaleURLs.pattern() {
+	case localeURLPath:
+		if prefix := pathLang(u.Path); i.supportedLanguage(prefix) != "" {
+			stripPathPrefix(u, "/"+prefix)
+		}
+	case localeURLSubdomain:
+		// The base URL is already the host without language.
+		if label, rest, ok := strings.Cut(u.Host, "."); ok && base == nil && i.supportedLanguage(label) != "" {
+			u.Host = rest
+		}
+	case localeURLQuery:
+		query := u.Query()
+		query.Del(i.LocaleURLs.queryParam())
+		u.RawQuery = query.Encode()
+	}
+	return u
+}
+
+// localizedURL returns the URL of neutral in lang according to the configured
+// pattern. neutral is not modified.
+func (i *I18n) localizedURL(neutral *url.URL, lang string) string {
+	u := *neutral
+	switch i.LocaleURLs.pattern() {
+	case localeURLPath:
+		u.Path = "/" + lang + u.Path
+		u.RawPath = ""
+	case localeURLSubdomain:
+		u.Host = strings.ToLower(lang) + "." + u.Host
+	case localeURLQuery:
+		query := u.Query()
+		query.Set(i.LocaleURLs.queryParam(), lang)
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// alternateLinks returns a <link rel="alternate" hreflang="..."> element for
+// every supported language and x-default, one per line.
+func (i *I18n) alternateLinks(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	neutral := i.neutralURL(r)
+
+	var sb strings.Builder
+	for _, lang := range i.supportedLanguages() {
+		writeAlternateLink(&sb, lang, i.localizedURL(neutral, lang))
+	}
+	xDefault := neutral.String()
+	if lang := i.LocaleURLs.xDefault(); lang != "" {
+		xDefault = i.localizedURL(neutral, lang)
+	}
+	writeAlternateLink(&sb, "x-default", xDefault)
+	return sb.String()
+}
+
+// writeAlternateLink writes one alternate link element to sb.
+func writeAlternateLink(sb *strings.Builder, hreflang, href string) {
+	fmt.Fprintf(sb, `<link rel="alternate" hreflang="%s" href="%s">`+"\n", html.EscapeString(hreflang), html.EscapeString(href))
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestAlternatesI18n(t *testing.T, cfg *LocaleURLConfig) *I18n {
+	t.Helper()
+	i18n := &I18n{
+		translations: map[string]map[string]string{"hello": {"de": "Hallo", "en": "Hello", "pt-BR": "Olá"}},
+		langCounts:   map[string]int{"de": 1, "en": 1, "pt-BR": 1},
+		LocaleURLs:   cfg,
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+	return i18n
+}
+
+func TestI18nAlternates(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *LocaleURLConfig
+		target   string
+		expected []string
+	}{
+		{
+			name:   "path prefix",
+			cfg:    nil,
+			target: "https://example.com/de/about?utm_source=mail&i18n_debug=1",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://example.com/de/about">`,
+				`<link rel="alternate" hreflang="en" href="https://example.com/en/about">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://example.com/pt-BR/about">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about">`,
+			},
+		},
+		{
+			name:   "path prefix keeping the query",
+			cfg:    &LocaleURLConfig{KeepQuery: true},
+			target: "https://example.com/de/about?page=2",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://example.com/de/about?page=2">`,
+				`<link rel="alternate" hreflang="en" href="https://example.com/en/about?page=2">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://example.com/pt-BR/about?page=2">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about?page=2">`,
+			},
+		},
+		{
+			name:   "subdomain",
+			cfg:    &LocaleURLConfig{Pattern: "subdomain", XDefault: "en"},
+			target: "https://de.example.com/about",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://de.example.com/about">`,
+				`<link rel="alternate" hreflang="en" href="https://en.example.com/about">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://pt-br.example.com/about">`,
+				`<link rel="alternate" hreflang="x-default" href="https://en.example.com/about">`,
+			},
+		},
+		{
+			name:   "base URL ignores host header",
+			cfg:    &LocaleURLConfig{BaseURL: "https://example.com"},
+			target: "http://attacker.test/de/about",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://example.com/de/about">`,
+				`<link rel="alternate" hreflang="en" href="https://example.com/en/about">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://example.com/pt-BR/about">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about">`,
+			},
+		},
+		{
+			name:   "subdomain with base URL",
+			cfg:    &LocaleURLConfig{Pattern: "subdomain", BaseURL: "https://example.com/"},
+			target: "https://de.attacker.test/about",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://de.example.com/about">`,
+				`<link rel="alternate" hreflang="en" href="https://en.example.com/about">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://pt-br.example.com/about">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about">`,
+			},
+		},
+		{
+			name:   "query parameter",
+			cfg:    &LocaleURLConfig{Pattern: "query", QueryParam: "hl"},
+			target: "https://example.com/about?hl=de&utm_source=mail&i18n_debug=1",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://example.com/about?hl=de">`,
+				`<link rel="alternate" hreflang="en" href="https://example.com/about?hl=en">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://example.com/about?hl=pt-BR">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about">`,
+			},
+		},
+		{
+			name:   "query parameter keeping the query",
+			cfg:    &LocaleURLConfig{Pattern: "query", QueryParam: "hl", KeepQuery: true},
+			target: "https://example.com/about?hl=de&a=1&b=<x>",
+			expected: []string{
+				`<link rel="alternate" hreflang="de" href="https://example.com/about?a=1&amp;b=%3Cx%3E&amp;hl=de">`,
+				`<link rel="alternate" hreflang="en" href="https://example.com/about?a=1&amp;b=%3Cx%3E&amp;hl=en">`,
+				`<link rel="alternate" hreflang="pt-BR" href="https://example.com/about?a=1&amp;b=%3Cx%3E&amp;hl=pt-BR">`,
+				`<link rel="alternate" hreflang="x-default" href="https://example.com/about?a=1&amp;b=%3Cx%3E">`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		i18n := newTestAlternatesI18n(t, tt.cfg)
+		altFunc := i18n.CustomTemplateFunctions()["i18nAlternates"].(func(*templates.TemplateContext) (string, error))
+
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		result, err := altFunc(&templates.TemplateContext{Req: req})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		expected := strings.Join(tt.expected, "\n") + "\n"
+		if result != expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.name, expected, result)
+		}
+	}
+}
+
+func TestI18nAlternatesUsesOriginalRequest(t *testing.T) {
+	i18n := newTestAlternatesI18n(t, nil)
+
+	orig := httptest.NewRequest(http.MethodGet, "/de/about", nil)
+	orig.TLS = &tls.ConnectionState{}
+	req := orig.Clone(context.WithValue(orig.Context(), caddyhttp.OriginalRequestCtxKey, *orig))
+	req.URL.Path = "/about.html"
+
+	result := i18n.alternateLinks(req)
+	if !strings.Contains(result, `hreflang="en" href="https://example.com/en/about"`) {
+		t.Errorf("expected links based on the original path, got\n%s", result)
+	}
+}
+
+func TestI18nAlternatesWithoutRequest(t *testing.T) {
+	i18n := newTestAlternatesI18n(t, nil)
+	if result := i18n.alternateLinks(nil); result != "" {
+		t.Errorf("expected empty result without request, got %q", result)
+	}
+}
+
+func TestLocaleURLConfigValidate(t *testing.T) {
+	if err := (&LocaleURLConfig{Pattern: "domain"}).validate(); err == nil {
+		t.Error("expected error for invalid pattern, got nil")
+	}
+	for _, base := range []string{"example.com", "ftp://example.com", "https://example.com/de", "https://", "https://example.com?x=1"} {
+		if err := (&LocaleURLConfig{BaseURL: base}).validate(); err == nil {
+			t.Errorf("expected error for base_url %q, got nil", base)
+		}
+	}
+	if err := (&LocaleURLConfig{BaseURL: "https://example.com:8443"}).validate(); err != nil {
+		t.Errorf("unexpected error for valid base_url: %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+)
+
+func init() {
+	caddy.RegisterModule(Bundle{})
+}
+
+// bundleFileRegexp matches the last path segment of bundle requests,
+// e.g. "de.json" or "en-US.js".
+var bundleFileRegexp = regexp.MustCompile(`^([A-Za-z0-9_-]+)\.(json|js)$`)
+
+// Bundle is an HTTP handler that serves the translations of one language as a
+// JSON object, so that client-side JavaScript can use the same strings as the
+// Caddy templates. Missing translations fall back to 'en', just like
+// i18nTranslate; keys without any translation are omitted.
+//
+// The language and format are taken from the last path segment:
+//   - .../de.json: {"hello": "Hallo Welt", ...}
+//   - .../de.js: export default {"hello": "Hallo Welt", ...};
+//
+// The optional prefix query parameter limits the bundle to keys starting with
+// the given prefix; it may be repeated. Requests for other paths are passed to
+// the next handler, and languages not found in the dictionary result in 404.
+//
+// Responses carry an ETag and a Cache-Control header and are gzip-compressed
+// if the client accepts it.
+type Bundle struct {
+	// DictFile is the path to the translations dictionary file in JSON format.
+	DictFile string `json:"dict_file,omitempty"`
+
+	// MaxAge sets the max-age of the Cache-Control header. If zero,
+	// clients must revalidate the bundle using its ETag.
+	MaxAge caddy.Duration `json:"max_age,omitempty"`
+
+	// dict holds the loaded dictionary.
+	dict *I18n
+}
+
+// CaddyModule returns the Caddy module information for registration.
+func (Bundle) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "http.handlers.i18n_bundle",
+		New: func() caddy.Module { return new(Bundle) },
+	}
+}
+
+// Provision loads the dictionary.
+func (b *Bundle) Provision(ctx caddy.Context) error {
+	if b.DictFile == "" {
+		return errors.New("i18n_bundle: dict_file is required")
+	}
+
+	dict, err := newDictionary(ctx, b.DictFile)
+	if err != nil {
+		return err
+	}
+	b.dict = dict
+	internalDictionaries.add(dict)
+	return nil
+}
+
+// Cleanup releases the loaded dictionary.
+func (b *Bundle) Cleanup() error {
+	if b.dict != nil {
+		internalDictionaries.remove(b.dict)
+	}
+	return nil
+}
+
+// ServeHTTP implements caddyhttp.MiddlewareHandler.
+func (b *Bundle) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	match := bundleFileRegexp.FindStringSubmatch(path.Base(r.URL.Path))
+	if match == nil {
+		return next.ServeHTTP(w, r)
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		return caddyhttp.Error(http.StatusMethodNotAllowed, nil)
+	}
+	lang, format := match[1], match[2]
+
+	if !b.dict.hasLanguage(lang) {
+		return caddyhttp.Error(http.StatusNotFound, fmt.Errorf("no translations for language %q", lang))
+	}
+
+	bundle, err := b.dict.bundleJSON(lang, r.URL.Query()["prefix"])
+	if err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	body := []byte(bundle)
+	contentType := "application/json"
+	if format == "js" {
+		body = append(append([]byte("export default "), body...), ";\n"...)
+		contentType = "text/javascript; charset=utf-8"
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Add("Vary", "Accept-Encoding")
+	if b.MaxAge > 0 {
+		h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(time.Duration(b.MaxAge).Seconds())))
+	} else {
+		h.Set("Cache-Control", "no-cache")
+	}
+
+	gzipped := acceptsGzip(r)
+	if gzipped {
+		etag = etag[:len(etag)-1] + `-gzip"`
+	}
+	h.Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	if gzipped {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return caddyhttp.Error(http.StatusInternalServerError, err)
+		}
+		if err := zw.Close(); err != nil {
+			return caddyhttp.Error(http.StatusInternalServerError, err)
+		}
+		body = buf.Bytes()
+		h.Set("Content-Encoding", "gzip")
+	}
+
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// bundle returns the translations of all keys starting with one of the given
+// prefixes in lang, falling back to 'en' and applying pseudo-localization like
+// i18nTranslate. Keys without a translation in either language are omitted.
+// If no prefix is given, all keys are included.
+func (i *I18n) bundle(lang string, prefixes []string) map[string]string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	pseudoLang := ""
+	if i.Pseudo.locale(lang) {
+		pseudoLang, lang = lang, i.Pseudo.sourceLang()
+	}
+
+	result := make(map[string]string)
+	for key, entry := range i.translations {
+		if !hasAnyPrefix(key, prefixes) {
+			continue
+		}
+		val, ok := entry[lang]
+		if !ok {
+			val, ok = entry["en"]
+		}
+		if !ok {
+			continue
+		}
+		if pseudoLang != "" {
+			val = i.Pseudo.transform(pseudoLang, val)
+		}
+		result[key] = val
+	}
+	return result
+}
+
+// bundleJSON returns the bundle of lang and prefixes as a JSON object. The
+// characters <, >, &, U+2028 and U+2029 are escaped, so the result can be
+// embedded in a <script> element as is.
+func (i *I18n) bundleJSON(lang string, prefixes []string) (string, error) {
+	data, err := json.Marshal(i.bundle(lang, prefixes))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// hasLanguage reports whether any key of the loaded dictionary has a
+// translation in lang.
+func (i *I18n) hasLanguage(lang string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, ok := i.langCounts[lang]
+	return ok
+}
+
+// hasAnyPrefix reports whether s starts with one of prefixes, or prefixes is empty.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsGzip reports whether the client accepts gzip-encoded responses.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
+
+// etagMatches reports whether an If-None-Match header matches etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Interface guards ensure that Bundle implements the required interfaces.
+var (
+	_ caddy.Provisioner           = (*Bundle)(nil)
+	_ caddy.CleanerUpper          = (*Bundle)(nil)
+	_ caddyhttp.MiddlewareHandler = (*Bundle)(nil)
+)
@@ -0,0 +1,256 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	dictFile := createTestDictFile(t, `{
+		"checkout.title": {"de": "Kasse", "en": "Checkout"},
+		"checkout.pay": {"en": "Pay now"},
+		"hello": {"de": "Hallo", "en": "Hello"},
+		"html": {"de": "<b>fett</b>"}
+	}`)
+
+	b := &Bundle{DictFile: dictFile, MaxAge: caddy.Duration(5 * time.Minute)}
+	var stubCaddyCtx caddy.Context
+	if err := b.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	t.Cleanup(func() { b.Cleanup() })
+	return b
+}
+
+func TestBundleDoesNotRegisterInstance(t *testing.T) {
+	b := newTestBundle(t)
+
+	if got := instances.forDictFile(b.DictFile); len(got) != 0 {
+		t.Errorf("expected no registered instances for the bundle dictionary, got %d", len(got))
+	}
+	if got := b.dict.langCounts; got["de"] != 3 || got["en"] != 3 {
+		t.Errorf("unexpected language counts %v", got)
+	}
+}
+
+func TestBundleFollowsEditorWrites(t *testing.T) {
+	b := newTestBundle(t)
+	e := newTestEditor(t, b.DictFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{"key": "hello", "lang": "fr", "value": "Bonjour"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	if err := e.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/i18n/fr.json", nil)
+	if err := b.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"hello":"Bonjour"`) {
+		t.Errorf("expected edited translation in bundle, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestBundleServesJSONWithFallbacks(t *testing.T) {
+	b := newTestBundle(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/i18n/de.json?prefix=checkout.", nil)
+	if err := b.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=300" {
+		t.Errorf("unexpected Cache-Control %q", cc)
+	}
+
+	var result map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode bundle: %v", err)
+	}
+	expected := map[string]string{"checkout.title": "Kasse", "checkout.pay": "Pay now"}
+	if len(result) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+	for key, val := range expected {
+		if result[key] != val {
+			t.Errorf("key %s: expected %q, got %q", key, val, result[key])
+		}
+	}
+}
+
+func TestBundleServesJSModule(t *testing.T) {
+	b := newTestBundle(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/i18n/en.js?prefix=hello", nil)
+	if err := b.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+
+	expected := "export default {\"hello\":\"Hello\"};\n"
+	if rec.Body.String() != expected {
+		t.Errorf("expected %q, got %q", expected, rec.Body.String())
+	}
+}
+
+func TestBundleETagAndGzip(t *testing.T) {
+	b := newTestBundle(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/de.json", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip")
+	rec := httptest.NewRecorder()
+	if err := b.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("expected gzip-encoded response")
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to read gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if !strings.Contains(string(body), `"hello":"Hallo"`) {
+		t.Errorf("unexpected body %s", body)
+	}
+	if !strings.Contains(string(body), `\u003cb\u003e`) {
+		t.Errorf("expected HTML characters to be escaped, got %s", body)
+	}
+
+	etag := rec.Header().Get("ETag")
+	if !strings.HasSuffix(etag, `-gzip"`) {
+		t.Errorf("expected encoding-specific ETag, got %q", etag)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/de.json", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	if err := b.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for matching ETag, got %d", rec.Code)
+	}
+}
+
+func TestBundleUnknownLanguageAndPaths(t *testing.T) {
+	b := newTestBundle(t)
+
+	err := b.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fr.json", nil), nextHandler)
+	var handlerErr caddyhttp.HandlerError
+	if !errors.As(err, &handlerErr) || handlerErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown language, got %v", err)
+	}
+
+	err = b.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/index.html", nil), nextHandler)
+	if err != errNextCalled {
+		t.Errorf("expected other paths to be passed on, got %v", err)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.5", true},
+		{"gzip;q=0", false},
+		{"br", false},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", tt.header)
+		if got := acceptsGzip(r); got != tt.expected {
+			t.Errorf("Accept-Encoding %q: expected %v, got %v", tt.header, tt.expected, got)
+		}
+	}
+}
+
+func TestI18nBundleTemplateFunction(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"checkout.title":  {"de": "Kasse", "en": "Checkout"},
+			"checkout.pay":    {"en": "Pay now"},
+			"checkout.script": {"de": "</script><script>alert(1)</script>\u2028"},
+			"checkout.none":   {"fr": "Rien"},
+			"hello":           {"de": "Hallo", "en": "Hello"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	bundleFunc := funcMap["i18nBundle"].(func(string, ...string) (string, error))
+
+	result, err := bundleFunc("de", "checkout.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"checkout.pay":"Pay now","checkout.script":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e\u2028","checkout.title":"Kasse"}`
+	if result != expected {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+
+	result, err = bundleFunc("en")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result, `"hello":"Hello"`) {
+		t.Errorf("expected all keys without prefix, got %s", result)
+	}
+}
+
+func TestI18nBundlePseudoLocale(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"en": "Hello"},
+		},
+		Pseudo: &PseudoConfig{Expansion: -1},
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	result, err := i18n.bundleJSON(pseudoAccented, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != `{"hello":"[Ĥéļļö]"}` {
+		t.Errorf("expected pseudo-localized bundle, got %s", result)
+	}
+}
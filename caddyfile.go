@@ -15,9 +15,23 @@
 package i18n
 
 import (
+	"strconv"
+
+	"github.com/caddyserver/caddy/v2"
 	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+	"github.com/caddyserver/caddy/v2/caddyconfig/httpcaddyfile"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
 )
 
+func init() {
+	httpcaddyfile.RegisterHandlerDirective("i18n_editor", parseEditorCaddyfile)
+	httpcaddyfile.RegisterDirectiveOrder("i18n_editor", httpcaddyfile.Before, "templates")
+	httpcaddyfile.RegisterHandlerDirective("i18n_bundle", parseBundleCaddyfile)
+	httpcaddyfile.RegisterDirectiveOrder("i18n_bundle", httpcaddyfile.Before, "templates")
+	httpcaddyfile.RegisterHandlerDirective("i18n_negotiate", parseNegotiateCaddyfile)
+	httpcaddyfile.RegisterDirectiveOrder("i18n_negotiate", httpcaddyfile.Before, "rewrite")
+}
+
 // UnmarshalCaddyfile deserializes Caddyfile tokens into the I18n struct.
 // It is called by Caddy when parsing Caddyfile configuration blocks.
 //
@@ -25,15 +39,52 @@ import (
 //
 //	i18n {
 //	    dict_file <path/to/dictionary.json>
+//	    required_languages <lang...>
+//	    max_missing_keys <n>
+//	    missing_log_interval <duration>
+//	    missing_log_level debug|info|warn|error
+//	    log_fallbacks true|false
+//	    pseudo {
+//	        expansion <percent>
+//	        source_lang <lang>
+//	    }
+//	    debug_keys {
+//	        query_param <name>
+//	        cookie <name>
+//	        header <name>
+//	        mode wrap|key|edit
+//	    }
+//	    lang_placeholders <placeholder...>
+//	    content_language true|false
+//	    locale_urls {
+//	        pattern path|subdomain|query
+//	        query_param <name>
+//	        x_default <lang>
+//	        base_url <url>
+//	        keep_query true|false
+//	    }
+//	    bidi_isolation auto|always|never
 //	}
 //
 // Parameters:
 //   - dict_file: Path to the JSON file containing translation dictionaries (required)
+//   - required_languages: Languages checked by the coverage report (optional)
+//   - max_missing_keys: Number of distinct runtime misses to collect, negative disables (optional, default 1000)
+//   - missing_log_interval: Log each missing translation at most once per interval (optional)
+//   - missing_log_level: Level for missing-translation logs (optional, default warn)
+//   - log_fallbacks: Whether to log fallbacks to 'en' (optional, default true)
+//   - pseudo: Enables the pseudo-locales en-XA and ar-XB; the block is optional (optional)
+//   - debug_keys: Allows showing translation keys per request; the block is optional (optional)
+//   - lang_placeholders: Placeholders i18nT reads the language from (optional, default {http.vars.lang} {http.i18n.lang})
+//   - content_language: Whether to set the Content-Language response header (optional, default true)
+//   - locale_urls: How page URLs differ between languages, used by i18nAlternates (optional, default path prefix)
+//   - bidi_isolation: When to wrap interpolated arguments in bidi isolates (optional, default never)
 //
 // Example:
 //
 //	i18n {
 //	    dict_file /etc/caddy/translations.json
+//	    required_languages de en fr
 //	}
 func (i *I18n) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
 	for d.Next() {
@@ -48,6 +99,199 @@ func (i *I18n) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
 					return d.ArgErr()
 				}
 
+			case "required_languages":
+				langs := d.RemainingArgs()
+				if len(langs) == 0 {
+					return d.ArgErr()
+				}
+				i.RequiredLanguages = append(i.RequiredLanguages, langs...)
+
+			case "max_missing_keys":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				n, err := strconv.Atoi(d.Val())
+				if err != nil {
+					return d.Errf("invalid max_missing_keys value %q: %v", d.Val(), err)
+				}
+				i.MaxMissingKeys = n
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "missing_log_interval":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				dur, err := caddy.ParseDuration(d.Val())
+				if err != nil {
+					return d.Errf("invalid missing_log_interval value %q: %v", d.Val(), err)
+				}
+				i.MissingLogInterval = caddy.Duration(dur)
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "missing_log_level":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				if _, err := parseMissingLogLevel(d.Val()); err != nil {
+					return d.Err(err.Error())
+				}
+				i.MissingLogLevel = d.Val()
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "log_fallbacks":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				enabled, err := strconv.ParseBool(d.Val())
+				if err != nil {
+					return d.Errf("invalid log_fallbacks value %q: %v", d.Val(), err)
+				}
+				i.LogFallbacks = &enabled
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "pseudo":
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+				i.Pseudo = &PseudoConfig{}
+				for pseudoNesting := d.Nesting(); d.NextBlock(pseudoNesting); {
+					switch d.Val() {
+					case "expansion":
+						if !d.NextArg() {
+							return d.ArgErr()
+						}
+						n, err := strconv.Atoi(d.Val())
+						if err != nil {
+							return d.Errf("invalid expansion value %q: %v", d.Val(), err)
+						}
+						i.Pseudo.Expansion = n
+						if d.NextArg() {
+							return d.ArgErr()
+						}
+
+					case "source_lang":
+						if !d.NextArg() {
+							return d.ArgErr()
+						}
+						i.Pseudo.SourceLang = d.Val()
+						if d.NextArg() {
+							return d.ArgErr()
+						}
+
+					default:
+						return d.Errf("unrecognized i18n pseudo property: %s", d.Val())
+					}
+				}
+
+			case "lang_placeholders":
+				placeholders := d.RemainingArgs()
+				if len(placeholders) == 0 {
+					return d.ArgErr()
+				}
+				i.LangPlaceholders = append(i.LangPlaceholders, placeholders...)
+
+			case "content_language":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				enabled, err := strconv.ParseBool(d.Val())
+				if err != nil {
+					return d.Errf("invalid content_language value %q: %v", d.Val(), err)
+				}
+				i.ContentLanguage = &enabled
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "debug_keys":
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+				i.DebugKeys = &DebugKeysConfig{}
+				for debugNesting := d.Nesting(); d.NextBlock(debugNesting); {
+					option := d.Val()
+					if !d.NextArg() {
+						return d.ArgErr()
+					}
+					switch option {
+					case "query_param":
+						i.DebugKeys.QueryParam = d.Val()
+					case "cookie":
+						i.DebugKeys.Cookie = d.Val()
+					case "header":
+						i.DebugKeys.Header = d.Val()
+					case "mode":
+						i.DebugKeys.Mode = d.Val()
+						if err := i.DebugKeys.validate(); err != nil {
+							return d.Err(err.Error())
+						}
+					default:
+						return d.Errf("unrecognized i18n debug_keys property: %s", option)
+					}
+					if d.NextArg() {
+						return d.ArgErr()
+					}
+				}
+
+			case "bidi_isolation":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				i.BidiIsolation = d.Val()
+				if err := validateBidiIsolation(i.BidiIsolation); err != nil {
+					return d.Err(err.Error())
+				}
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "locale_urls":
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+				i.LocaleURLs = &LocaleURLConfig{}
+				for urlNesting := d.Nesting(); d.NextBlock(urlNesting); {
+					option := d.Val()
+					if !d.NextArg() {
+						return d.ArgErr()
+					}
+					switch option {
+					case "pattern":
+						i.LocaleURLs.Pattern = d.Val()
+						if err := i.LocaleURLs.validate(); err != nil {
+							return d.Err(err.Error())
+						}
+					case "query_param":
+						i.LocaleURLs.QueryParam = d.Val()
+					case "x_default":
+						i.LocaleURLs.XDefault = d.Val()
+					case "base_url":
+						i.LocaleURLs.BaseURL = d.Val()
+						if err := i.LocaleURLs.validate(); err != nil {
+							return d.Err(err.Error())
+						}
+					case "keep_query":
+						keep, err := strconv.ParseBool(d.Val())
+						if err != nil {
+							return d.Errf("invalid locale_urls keep_query value %q: %v", d.Val(), err)
+						}
+						i.LocaleURLs.KeepQuery = keep
+					default:
+						return d.Errf("unrecognized i18n locale_urls property: %s", option)
+					}
+					if d.NextArg() {
+						return d.ArgErr()
+					}
+				}
+
 			default:
 				return d.Errf("unrecognized i18n config property: %s", d.Val())
 			}
@@ -56,5 +300,248 @@ func (i *I18n) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
 	return nil
 }
 
-// Interface guard ensures that I18n implements caddyfile.Unmarshaler.
-var _ caddyfile.Unmarshaler = (*I18n)(nil)
+// parseEditorCaddyfile sets up the i18n_editor handler from Caddyfile tokens.
+func parseEditorCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error) {
+	e := new(Editor)
+	err := e.UnmarshalCaddyfile(h.Dispenser)
+	return e, err
+}
+
+// UnmarshalCaddyfile deserializes Caddyfile tokens into the Editor struct.
+//
+// Syntax:
+//
+//	i18n_editor [<matcher>] {
+//	    dict_file <path/to/dictionary.json>
+//	    token <secret>
+//	}
+//
+// Parameters:
+//   - dict_file: Path to the dictionary file edits are written to (required)
+//   - token: Secret the overlay must send as bearer token; placeholders are supported (required)
+//
+// Example:
+//
+//	route /_i18n/* {
+//	    i18n_editor {
+//	        dict_file ./demo/translations.json
+//	        token {env.I18N_EDITOR_TOKEN}
+//	    }
+//	}
+func (e *Editor) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
+	for d.Next() {
+		if d.NextArg() {
+			return d.ArgErr()
+		}
+		for nesting := d.Nesting(); d.NextBlock(nesting); {
+			switch d.Val() {
+			case "dict_file":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				e.DictFile = d.Val()
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "token":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				e.Token = d.Val()
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			default:
+				return d.Errf("unrecognized i18n_editor config property: %s", d.Val())
+			}
+		}
+	}
+	return nil
+}
+
+// parseBundleCaddyfile sets up the i18n_bundle handler from Caddyfile tokens.
+func parseBundleCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error) {
+	b := new(Bundle)
+	err := b.UnmarshalCaddyfile(h.Dispenser)
+	return b, err
+}
+
+// UnmarshalCaddyfile deserializes Caddyfile tokens into the Bundle struct.
+//
+// Syntax:
+//
+//	i18n_bundle [<matcher>] {
+//	    dict_file <path/to/dictionary.json>
+//	    max_age <duration>
+//	}
+//
+// Parameters:
+//   - dict_file: Path to the JSON file containing translation dictionaries (required)
+//   - max_age: max-age of the Cache-Control header (optional, default revalidate using ETag)
+//
+// Example:
+//
+//	handle /i18n/* {
+//	    i18n_bundle {
+//	        dict_file ./demo/translations.json
+//	        max_age 5m
+//	    }
+//	}
+func (b *Bundle) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
+	for d.Next() {
+		if d.NextArg() {
+			return d.ArgErr()
+		}
+		for nesting := d.Nesting(); d.NextBlock(nesting); {
+			switch d.Val() {
+			case "dict_file":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				b.DictFile = d.Val()
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "max_age":
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				dur, err := caddy.ParseDuration(d.Val())
+				if err != nil {
+					return d.Errf("invalid max_age value %q: %v", d.Val(), err)
+				}
+				b.MaxAge = caddy.Duration(dur)
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			default:
+				return d.Errf("unrecognized i18n_bundle config property: %s", d.Val())
+			}
+		}
+	}
+	return nil
+}
+
+// parseNegotiateCaddyfile sets up the i18n_negotiate handler from Caddyfile tokens.
+func parseNegotiateCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error) {
+	n := new(Negotiate)
+	err := n.UnmarshalCaddyfile(h.Dispenser)
+	return n, err
+}
+
+// UnmarshalCaddyfile deserializes Caddyfile tokens into the Negotiate struct.
+//
+// Syntax:
+//
+//	i18n_negotiate [<matcher>] {
+//	    dict_file <path/to/dictionary.json>
+//	    order <source...>
+//	    query_param <name>
+//	    cookie <name>
+//	    default <lang>
+//	    persist [<max_age>]
+//	    strip_prefix
+//	    redirect
+//	}
+//
+// Parameters:
+//   - dict_file: Path to the JSON file containing translation dictionaries (required)
+//   - order: Sources to consult: path, query, cookie, header (optional, default all in this order)
+//   - query_param: Name of the query parameter (optional, default lang)
+//   - cookie: Name of the cookie (optional, default lang)
+//   - default: Language used if no source matches (optional, default en)
+//   - persist: Store a language chosen by path or query in the cookie (optional, default max_age 1 year)
+//   - strip_prefix: Remove the language prefix from the path, e.g. /de/about becomes /about (optional)
+//   - redirect: Redirect requests without language prefix to the negotiated locale (optional)
+//
+// Example:
+//
+//	i18n_negotiate {
+//	    dict_file ./demo/translations.json
+//	    order query cookie header
+//	    persist 30d
+//	}
+//
+// Example for locale-prefixed URLs such as /de/about:
+//
+//	i18n_negotiate {
+//	    dict_file ./demo/translations.json
+//	    strip_prefix
+//	    redirect
+//	}
+func (n *Negotiate) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
+	for d.Next() {
+		if d.NextArg() {
+			return d.ArgErr()
+		}
+		for nesting := d.Nesting(); d.NextBlock(nesting); {
+			switch d.Val() {
+			case "dict_file", "query_param", "cookie", "default":
+				prop := d.Val()
+				if !d.NextArg() {
+					return d.ArgErr()
+				}
+				switch prop {
+				case "dict_file":
+					n.DictFile = d.Val()
+				case "query_param":
+					n.QueryParam = d.Val()
+				case "cookie":
+					n.Cookie = d.Val()
+				case "default":
+					n.Default = d.Val()
+				}
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "order":
+				n.Order = d.RemainingArgs()
+				if len(n.Order) == 0 {
+					return d.ArgErr()
+				}
+
+			case "persist":
+				n.Persist = true
+				if d.NextArg() {
+					dur, err := caddy.ParseDuration(d.Val())
+					if err != nil {
+						return d.Errf("invalid persist max_age value %q: %v", d.Val(), err)
+					}
+					n.PersistMaxAge = caddy.Duration(dur)
+				}
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "strip_prefix":
+				n.StripPrefix = true
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			case "redirect":
+				n.Redirect = true
+				if d.NextArg() {
+					return d.ArgErr()
+				}
+
+			default:
+				return d.Errf("unrecognized i18n_negotiate config property: %s", d.Val())
+			}
+		}
+	}
+	return nil
+}
+
+// Interface guards ensure that the modules implement caddyfile.Unmarshaler.
+var (
+	_ caddyfile.Unmarshaler = (*I18n)(nil)
+	_ caddyfile.Unmarshaler = (*Editor)(nil)
+	_ caddyfile.Unmarshaler = (*Bundle)(nil)
+	_ caddyfile.Unmarshaler = (*Negotiate)(nil)
+)
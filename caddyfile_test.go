@@ -17,8 +17,12 @@ package i18n
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+	"github.com/caddyserver/caddy/v2/caddyconfig/httpcaddyfile"
+	_ "github.com/caddyserver/caddy/v2/modules/caddyhttp/fileserver"
+	_ "github.com/caddyserver/caddy/v2/modules/caddyhttp/rewrite"
 )
 
 func TestUnmarshalCaddyfileBasic(t *testing.T) {
@@ -177,3 +181,436 @@ func TestUnmarshalCaddyfileInterfaceGuard(t *testing.T) {
 		t.Fatal("I18n should implement caddyfile.Unmarshaler")
 	}
 }
+
+func TestUnmarshalCaddyfileRequiredLanguages(t *testing.T) {
+	input := `i18n {
+		dict_file /path/to/dict.json
+		required_languages de en
+		required_languages fr
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	expected := []string{"de", "en", "fr"}
+	if strings.Join(i18n.RequiredLanguages, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected RequiredLanguages %v, got %v", expected, i18n.RequiredLanguages)
+	}
+}
+
+func TestUnmarshalCaddyfileRequiredLanguagesMissingValue(t *testing.T) {
+	input := `i18n {
+		required_languages
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Fatal("expected error for missing required_languages value")
+	}
+}
+
+func TestUnmarshalCaddyfileMaxMissingKeys(t *testing.T) {
+	input := `i18n {
+		max_missing_keys 250
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if i18n.MaxMissingKeys != 250 {
+		t.Errorf("expected MaxMissingKeys 250, got %d", i18n.MaxMissingKeys)
+	}
+}
+
+func TestUnmarshalCaddyfileMaxMissingKeysInvalid(t *testing.T) {
+	input := `i18n {
+		max_missing_keys many
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Fatal("expected error for non-numeric max_missing_keys")
+	}
+}
+
+func TestUnmarshalCaddyfileLogOptions(t *testing.T) {
+	input := `i18n {
+		missing_log_interval 5m
+		missing_log_level info
+		log_fallbacks false
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if time.Duration(i18n.MissingLogInterval) != 5*time.Minute {
+		t.Errorf("expected MissingLogInterval 5m, got %v", time.Duration(i18n.MissingLogInterval))
+	}
+	if i18n.MissingLogLevel != "info" {
+		t.Errorf("expected MissingLogLevel 'info', got %q", i18n.MissingLogLevel)
+	}
+	if i18n.LogFallbacks == nil || *i18n.LogFallbacks {
+		t.Errorf("expected LogFallbacks false, got %v", i18n.LogFallbacks)
+	}
+}
+
+func TestUnmarshalCaddyfileInvalidLogLevel(t *testing.T) {
+	input := `i18n {
+		missing_log_level verbose
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Fatal("expected error for invalid missing_log_level")
+	}
+}
+
+func TestUnmarshalCaddyfilePseudo(t *testing.T) {
+	input := `i18n {
+		pseudo {
+			expansion 40
+			source_lang de
+		}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if i18n.Pseudo == nil {
+		t.Fatal("expected Pseudo to be set")
+	}
+	if i18n.Pseudo.Expansion != 40 || i18n.Pseudo.SourceLang != "de" {
+		t.Errorf("unexpected pseudo config: %+v", i18n.Pseudo)
+	}
+}
+
+func TestUnmarshalCaddyfilePseudoWithoutBlock(t *testing.T) {
+	input := `i18n {
+		pseudo
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if i18n.Pseudo == nil || i18n.Pseudo.Expansion != 0 {
+		t.Errorf("expected default pseudo config, got %+v", i18n.Pseudo)
+	}
+}
+
+func TestUnmarshalCaddyfileDebugKeys(t *testing.T) {
+	input := `i18n {
+		debug_keys {
+			query_param show_keys
+			cookie show_keys
+			header X-Show-Keys
+			mode key
+		}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	expected := DebugKeysConfig{QueryParam: "show_keys", Cookie: "show_keys", Header: "X-Show-Keys", Mode: "key"}
+	if i18n.DebugKeys == nil || *i18n.DebugKeys != expected {
+		t.Errorf("expected %+v, got %+v", expected, i18n.DebugKeys)
+	}
+}
+
+func TestUnmarshalCaddyfileDebugKeysInvalidMode(t *testing.T) {
+	input := `i18n {
+		debug_keys {
+			mode verbose
+		}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Fatal("expected error for invalid debug_keys mode")
+	}
+}
+
+func TestUnmarshalCaddyfileEditor(t *testing.T) {
+	input := `i18n_editor {
+		dict_file ./translations.json
+		token {env.I18N_EDITOR_TOKEN}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	e := &Editor{}
+
+	err := e.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if e.DictFile != "./translations.json" {
+		t.Errorf("expected DictFile './translations.json', got %q", e.DictFile)
+	}
+	if e.Token != "{env.I18N_EDITOR_TOKEN}" {
+		t.Errorf("expected Token placeholder, got %q", e.Token)
+	}
+}
+
+func TestUnmarshalCaddyfileEditorUnknownProperty(t *testing.T) {
+	input := `i18n_editor {
+		password secret
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	e := &Editor{}
+
+	err := e.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Fatal("expected error for unknown property")
+	}
+}
+
+func TestUnmarshalCaddyfileBundle(t *testing.T) {
+	input := `i18n_bundle {
+		dict_file ./translations.json
+		max_age 10m
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	b := &Bundle{}
+
+	err := b.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if b.DictFile != "./translations.json" {
+		t.Errorf("expected DictFile './translations.json', got %q", b.DictFile)
+	}
+	if time.Duration(b.MaxAge) != 10*time.Minute {
+		t.Errorf("expected MaxAge 10m, got %v", time.Duration(b.MaxAge))
+	}
+}
+
+func TestUnmarshalCaddyfileLangPlaceholders(t *testing.T) {
+	input := `i18n {
+		lang_placeholders {http.vars.lang} {http.request.cookie.lang}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	expected := "{http.vars.lang},{http.request.cookie.lang}"
+	if strings.Join(i18n.LangPlaceholders, ",") != expected {
+		t.Errorf("expected LangPlaceholders %q, got %v", expected, i18n.LangPlaceholders)
+	}
+}
+
+func TestUnmarshalCaddyfileNegotiate(t *testing.T) {
+	input := `i18n_negotiate {
+		dict_file ./translations.json
+		order query cookie header
+		query_param hl
+		cookie site_lang
+		default de
+		persist 720h
+		strip_prefix
+		redirect
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	n := &Negotiate{}
+
+	err := n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if n.DictFile != "./translations.json" {
+		t.Errorf("expected DictFile './translations.json', got %q", n.DictFile)
+	}
+	if strings.Join(n.Order, ",") != "query,cookie,header" {
+		t.Errorf("unexpected Order %v", n.Order)
+	}
+	if n.QueryParam != "hl" || n.Cookie != "site_lang" || n.Default != "de" {
+		t.Errorf("unexpected QueryParam %q, Cookie %q or Default %q", n.QueryParam, n.Cookie, n.Default)
+	}
+	if !n.Persist || time.Duration(n.PersistMaxAge) != 720*time.Hour {
+		t.Errorf("expected Persist with 720h, got %v with %v", n.Persist, time.Duration(n.PersistMaxAge))
+	}
+	if !n.StripPrefix || !n.Redirect {
+		t.Errorf("expected StripPrefix and Redirect, got %v and %v", n.StripPrefix, n.Redirect)
+	}
+}
+
+func TestUnmarshalCaddyfileNegotiateUnknownProperty(t *testing.T) {
+	input := `i18n_negotiate {
+		languages de en
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	n := &Negotiate{}
+
+	err := n.UnmarshalCaddyfile(d)
+	if err == nil {
+		t.Error("expected error for unknown property, got nil")
+	}
+}
+
+func TestUnmarshalCaddyfileContentLanguage(t *testing.T) {
+	input := `i18n {
+		content_language false
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if i18n.ContentLanguage == nil || *i18n.ContentLanguage {
+		t.Errorf("expected ContentLanguage false, got %v", i18n.ContentLanguage)
+	}
+}
+
+func TestUnmarshalCaddyfileLocaleURLs(t *testing.T) {
+	input := `i18n {
+		locale_urls {
+			pattern query
+			query_param hl
+			x_default en
+			base_url https://example.com
+			keep_query true
+		}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	err := i18n.UnmarshalCaddyfile(d)
+	if err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+
+	if i18n.LocaleURLs == nil {
+		t.Fatal("expected LocaleURLs to be set")
+	}
+	if i18n.LocaleURLs.Pattern != "query" || i18n.LocaleURLs.QueryParam != "hl" || i18n.LocaleURLs.XDefault != "en" ||
+		i18n.LocaleURLs.BaseURL != "https://example.com" || !i18n.LocaleURLs.KeepQuery {
+		t.Errorf("unexpected LocaleURLs %+v", *i18n.LocaleURLs)
+	}
+}
+
+func TestUnmarshalCaddyfileLocaleURLsInvalidPattern(t *testing.T) {
+	input := `i18n {
+		locale_urls {
+			pattern domain
+		}
+	}`
+
+	d := caddyfile.NewTestDispenser(input)
+	i18n := &I18n{}
+
+	if err := i18n.UnmarshalCaddyfile(d); err == nil {
+		t.Error("expected error for invalid pattern, got nil")
+	}
+}
+
+func TestUnmarshalCaddyfileBidiIsolation(t *testing.T) {
+	d := caddyfile.NewTestDispenser(`i18n {
+		bidi_isolation always
+	}`)
+	i18n := &I18n{}
+
+	if err := i18n.UnmarshalCaddyfile(d); err != nil {
+		t.Fatalf("UnmarshalCaddyfile failed: %v", err)
+	}
+	if i18n.BidiIsolation != "always" {
+		t.Errorf("expected BidiIsolation 'always', got %q", i18n.BidiIsolation)
+	}
+
+	d = caddyfile.NewTestDispenser(`i18n {
+		bidi_isolation rtl
+	}`)
+	if err := (&I18n{}).UnmarshalCaddyfile(d); err == nil {
+		t.Error("expected error for invalid bidi_isolation, got nil")
+	}
+}
+
+func TestNegotiateDirectiveOrder(t *testing.T) {
+	input := `:8080 {
+		file_server
+		templates
+		try_files {path} /index.html
+		rewrite /old /new
+		i18n_negotiate {
+			dict_file /path/to/dict.json
+			strip_prefix
+		}
+	}`
+
+	adapter := caddyfile.Adapter{ServerType: httpcaddyfile.ServerType{}}
+	out, _, err := adapter.Adapt([]byte(input), nil)
+	if err != nil {
+		t.Fatalf("Adapt failed: %v", err)
+	}
+
+	cfg := string(out)
+	negotiate := strings.Index(cfg, `"handler":"i18n_negotiate"`)
+	if negotiate < 0 {
+		t.Fatalf("i18n_negotiate handler not found in %s", cfg)
+	}
+	for _, handler := range []string{"rewrite", "templates", "file_server"} {
+		idx := strings.Index(cfg, `"handler":"`+handler+`"`)
+		if idx < 0 {
+			t.Fatalf("%s handler not found in %s", handler, cfg)
+		}
+		if idx < negotiate {
+			t.Errorf("expected i18n_negotiate before %s in %s", handler, cfg)
+		}
+	}
+}
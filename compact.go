@@ -0,0 +1,181 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+)
+
+// compactPatterns holds the CLDR compact decimal patterns of a locale for
+// thousands, millions, billions and trillions in the short and long widths.
+// The run of zeros stands for the number and its length for the number of
+// integer digits shown, e.g. "0K" is used for 1,000 to 9,999 and, with
+// added zeros, "00K" for 10,000 to 99,999. A pattern of zeros only leaves
+// the number uncompacted.
+type compactPatterns struct {
+	short, long [4]pluralForms
+}
+
+// compactData contains the compact decimal patterns of the bundled locales.
+var compactData = map[string]compactPatterns{
+	"de": {
+		short: [4]pluralForms{{"other": "0\u00a0Tsd."}, {"other": "0\u00a0Mio."}, {"other": "0\u00a0Mrd."}, {"other": "0\u00a0Bio."}},
+		long: [4]pluralForms{
+			{"other": "0 Tausend"},
+			{"one": "0 Million", "other": "0 Millionen"},
+			{"one": "0 Milliarde", "other": "0 Milliarden"},
+			{"one": "0 Billion", "other": "0 Billionen"},
+		},
+	},
+	"en": {
+		short: [4]pluralForms{{"other": "0K"}, {"other": "0M"}, {"other": "0B"}, {"other": "0T"}},
+		long:  [4]pluralForms{{"other": "0 thousand"}, {"other": "0 million"}, {"other": "0 billion"}, {"other": "0 trillion"}},
+	},
+	"es": {
+		short: [4]pluralForms{{"other": "0\u00a0mil"}, {"other": "0\u00a0M"}, {"other": "0000\u00a0M"}, {"other": "0\u00a0B"}},
+		long: [4]pluralForms{
+			{"other": "0 mil"},
+			{"one": "0 millón", "other": "0 millones"},
+			{"other": "0 mil millones"},
+			{"one": "0 billón", "other": "0 billones"},
+		},
+	},
+	"fr": {
+		short: [4]pluralForms{{"other": "0\u00a0k"}, {"other": "0\u00a0M"}, {"other": "0\u00a0Md"}, {"other": "0\u00a0Bn"}},
+		long: [4]pluralForms{
+			{"one": "0 millier", "other": "0 mille"},
+			{"one": "0 million", "other": "0 millions"},
+			{"one": "0 milliard", "other": "0 milliards"},
+			{"one": "0 billion", "other": "0 billions"},
+		},
+	},
+	"it": {
+		short: [4]pluralForms{{"other": "0"}, {"other": "0\u00a0Mln"}, {"other": "0\u00a0Mrd"}, {"other": "0\u00a0Bln"}},
+		long: [4]pluralForms{
+			{"one": "mille", "other": "0 mila"},
+			{"one": "0 milione", "other": "0 milioni"},
+			{"one": "0 miliardo", "other": "0 miliardi"},
+			{"one": "0 mille miliardi", "other": "0 mila miliardi"},
+		},
+	},
+	"nl": {
+		short: [4]pluralForms{{"other": "0K"}, {"other": "0\u00a0mln."}, {"other": "0\u00a0mld."}, {"other": "0\u00a0bln."}},
+		long:  [4]pluralForms{{"other": "0 duizend"}, {"other": "0 miljoen"}, {"other": "0 miljard"}, {"other": "0 biljoen"}},
+	},
+	"pt": {
+		short: [4]pluralForms{{"other": "0\u00a0mil"}, {"other": "0\u00a0mi"}, {"other": "0\u00a0bi"}, {"other": "0\u00a0tri"}},
+		long: [4]pluralForms{
+			{"other": "0 mil"},
+			{"one": "0 milhão", "other": "0 milhões"},
+			{"one": "0 bilhão", "other": "0 bilhões"},
+			{"one": "0 trilhão", "other": "0 trilhões"},
+		},
+	},
+}
+
+// zeroRun returns the position and length of the first run of zeros in
+// pattern, or -1 and 0 if there is none.
+func zeroRun(pattern string) (start, length int) {
+	start = strings.IndexByte(pattern, '0')
+	if start < 0 {
+		return -1, 0
+	}
+	for start+length < len(pattern) && pattern[start+length] == '0' {
+		length++
+	}
+	return start, length
+}
+
+// formatCompact formats value in compact notation for lang, e.g. "1,2 Tsd."
+// in German or "1.2 thousand" in English with the long option. The options
+// are a width (short or long; default short) and number options as in
+// i18nNumber. As in CLDR, numbers with a single integer digit are shown with
+// up to one fraction digit and others without fraction digits.
+func formatCompact(value interface{}, lang, opts string) (string, error) {
+	d, err := toDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	width := widthShort
+	var numberOpts []string
+	for _, opt := range strings.Fields(opts) {
+		if opt == widthShort || opt == widthLong {
+			width = opt
+		} else {
+			numberOpts = append(numberOpts, opt)
+		}
+	}
+	numberOpt := strings.Join(numberOpts, " ")
+	if _, err := parseNumberOptions(numberOpt, defaultNumberOptions); err != nil {
+		return "", err
+	}
+
+	patterns, ok := findLocale(compactData, lang)
+	if !ok {
+		// A locale without bundled compact patterns is formatted
+		// entirely in English, e.g. "1.2K" rather than "١٫٢K".
+		lang = "en"
+	}
+	groups := patterns.short
+	if width == widthLong {
+		groups = patterns.long
+	}
+	sym := symbolsFor(lang)
+
+	// The exponent of the number, e.g. 3 for 1,234
+	exponent := len(d.Abs().Truncate(0).String()) - 1
+	for {
+		forms := pluralForms{"other": "0"}
+		extra := 0
+		if exponent >= 3 {
+			group := min(exponent/3-1, len(groups)-1)
+			forms = groups[group]
+			extra = exponent - 3*(group+1)
+		}
+		_, digits := zeroRun(forms["other"])
+		digits += extra
+		if forms["other"] == "0" {
+			// Not compacted
+			digits = exponent + 1
+		}
+
+		scaled := d.Shift(int32(digits - exponent - 1))
+		defaults := defaultNumberOptions
+		defaults.maxFraction = 0
+		if digits == 1 {
+			defaults.maxFraction = 1
+		}
+		options, _ := parseNumberOptions(numberOpt, defaults)
+		intPart, fracPart := plainDecimal(scaled, options)
+		if len(intPart) > digits {
+			// Rounding reached the next power of ten, e.g. 999,950 to "1000K"
+			exponent++
+			continue
+		}
+
+		number := sym.sign(scaled, options, sym.formatDecimal(scaled, options))
+		if forms["other"] == "0" {
+			return number, nil
+		}
+		pattern := forms.pick(decimalPluralCategory(plural.Cardinal, lang, intPart, fracPart))
+		start, length := zeroRun(pattern)
+		if start < 0 {
+			return pattern, nil
+		}
+		return pattern[:start] + number + pattern[start+length:], nil
+	}
+}
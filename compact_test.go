@@ -0,0 +1,117 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatCompact(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		lang     string
+		opts     string
+		expected string
+	}{
+		{1234, "en", "", "1.2K"},
+		{1234, "de", "", "1,2\u00a0Tsd."},
+		{1234, "en", "long", "1.2 thousand"},
+		{1234, "de", "long", "1,2 Tausend"},
+		{12345, "en", "", "12K"},
+		{123456, "en", "", "123K"},
+		{1000, "en", "", "1K"},
+		{999, "en", "", "999"},
+		{9.96, "en", "", "10"},
+		{1.25, "en", "", "1.2"},
+		{0, "en", "", "0"},
+		{-1234, "de", "", "-1,2\u00a0Tsd."},
+		{999950, "en", "", "1M"},
+		{1500000, "de", "long", "1,5 Millionen"},
+		{1000000, "de", "long", "1 Million"},
+		{2000000000, "de", "", "2\u00a0Mrd."},
+		{1500000000, "es", "", "1500\u00a0M"},
+		{3e12, "en", "long", "3 trillion"},
+		{4.2e15, "en", "", "4,200T"},
+		{1000, "fr", "long", "1 millier"},
+		{2000, "fr", "long", "2 mille"},
+		{1000, "it", "long", "mille"},
+		{5000, "it", "long", "5 mila"},
+		{5000, "it", "", "5.000"},
+		{1234, "en", "max=2", "1.23K"},
+		{"2500000", "pt-BR", "", "2,5\u00a0mi"},
+
+		// Unbundled locales are formatted entirely in English
+		{1234, "sv", "", "1.2K"},
+		{1234, "ar", "", "1.2K"},
+		{1500000, "ja", "long", "1.5 million"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatCompact(tt.value, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatCompact(%v, %q, %q): unexpected error: %v", tt.value, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatCompact(%v, %q, %q): expected %q, got %q", tt.value, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatCompactErrors(t *testing.T) {
+	if _, err := formatCompact("lots", "en", ""); err == nil {
+		t.Error("expected error for invalid number, got nil")
+	}
+	if _, err := formatCompact(1234, "en", "narrow"); err == nil {
+		t.Error("expected error for invalid option, got nil")
+	}
+}
+
+func TestPercentAndCompactPlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"profile.stats": {
+				"de": "{0, compact} Follower, {1, percent} Interaktionsrate",
+				"en": "{0, compact, long} followers, {1, percent, max=1} engagement",
+			},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	args := []interface{}{1234, 0.125}
+	if result := i18n.translate(nil, "profile.stats", "de", args); result != "1,2\u00a0Tsd. Follower, 12\u00a0% Interaktionsrate" {
+		t.Errorf("unexpected German result %q", result)
+	}
+	if result := i18n.translate(nil, "profile.stats", "en", args); result != "1.2 thousand followers, 12.5% engagement" {
+		t.Errorf("unexpected English result %q", result)
+	}
+}
+
+func TestI18nPercentAndCompactFunctions(t *testing.T) {
+	funcMap := (&I18n{}).CustomTemplateFunctions()
+	percentFunc := funcMap["i18nPercent"].(func(interface{}, string, ...string) (string, error))
+	compactFunc := funcMap["i18nCompact"].(func(interface{}, string, ...string) (string, error))
+
+	if result, err := percentFunc(0.12, "de"); err != nil || result != "12\u00a0%" {
+		t.Errorf("expected '12 %%', got %q (%v)", result, err)
+	}
+	if result, err := compactFunc(1234, "en", "long"); err != nil || result != "1.2 thousand" {
+		t.Errorf("expected '1.2 thousand', got %q (%v)", result, err)
+	}
+}
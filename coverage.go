@@ -0,0 +1,142 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// CoverageReport describes how completely a translation dictionary covers
+// a set of languages.
+type CoverageReport struct {
+	// DictFile is the dictionary file the report was generated for.
+	DictFile string `json:"dict_file"`
+
+	// TotalKeys is the number of translation keys in the dictionary.
+	TotalKeys int `json:"total_keys"`
+
+	// Languages holds one entry per checked language, in the order checked.
+	Languages []LanguageCoverage `json:"languages"`
+}
+
+// LanguageCoverage describes the translation gaps of a single language.
+type LanguageCoverage struct {
+	// Language is the language code, e.g. "fr".
+	Language string `json:"language"`
+
+	// Translated is the number of keys with a non-empty translation.
+	Translated int `json:"translated"`
+
+	// Missing is the number of keys without a translation.
+	Missing int `json:"missing"`
+
+	// Percent is the share of translated keys, from 0 to 100.
+	Percent float64 `json:"percent"`
+
+	// MissingKeys lists the keys without a translation, sorted alphabetically.
+	MissingKeys []string `json:"missing_keys"`
+}
+
+// coverageReport compares every key in the loaded translations against the
+// given languages. If langs is empty, the configured RequiredLanguages are
+// used, and if those are empty too, every language found in the dictionary
+// is checked. Empty translations count as missing.
+func (i *I18n) coverageReport(langs []string) CoverageReport {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(langs) == 0 {
+		langs = i.RequiredLanguages
+	}
+	if len(langs) == 0 {
+		langs = i.dictionaryLanguages()
+	}
+
+	keys := make([]string, 0, len(i.translations))
+	for key := range i.translations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := CoverageReport{
+		DictFile:  i.DictFile,
+		TotalKeys: len(keys),
+		Languages: make([]LanguageCoverage, 0, len(langs)),
+	}
+
+	for _, lang := range langs {
+		cov := LanguageCoverage{
+			Language:    lang,
+			MissingKeys: []string{},
+		}
+		for _, key := range keys {
+			if i.translations[key][lang] == "" {
+				cov.MissingKeys = append(cov.MissingKeys, key)
+				continue
+			}
+			cov.Translated++
+		}
+		cov.Missing = len(cov.MissingKeys)
+		if len(keys) > 0 {
+			cov.Percent = float64(cov.Translated) * 100 / float64(len(keys))
+		} else {
+			cov.Percent = 100
+		}
+		report.Languages = append(report.Languages, cov)
+	}
+
+	return report
+}
+
+// dictionaryLanguages returns all language codes used in the loaded
+// translations, sorted alphabetically. The caller must hold i.mu.
+func (i *I18n) dictionaryLanguages() []string {
+	set := make(map[string]struct{})
+	for _, entry := range i.translations {
+		for lang := range entry {
+			set[lang] = struct{}{}
+		}
+	}
+
+	langs := make([]string, 0, len(set))
+	for lang := range set {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// logCoverage writes the completion percentage of every checked language to
+// the log. Languages with gaps are logged as warnings including the missing keys.
+func (i *I18n) logCoverage() {
+	report := i.coverageReport(nil)
+	for _, cov := range report.Languages {
+		fields := []zap.Field{
+			zap.String("dict_file", report.DictFile),
+			zap.String("lang", cov.Language),
+			zap.Int("translated", cov.Translated),
+			zap.Int("total_keys", report.TotalKeys),
+			zap.Float64("percent", cov.Percent),
+		}
+		if cov.Missing == 0 {
+			i.logger.Info("i18n dictionary coverage", fields...)
+			continue
+		}
+		i.logger.Warn("i18n dictionary has missing translations",
+			append(fields, zap.Strings("missing_keys", cov.MissingKeys))...)
+	}
+}
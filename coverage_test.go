@@ -0,0 +1,151 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCoverageReportRequiredLanguages(t *testing.T) {
+	i18n := &I18n{
+		DictFile:          "dict.json",
+		RequiredLanguages: []string{"de", "fr"},
+		translations: map[string]map[string]string{
+			"hello":   {"de": "Hallo", "en": "Hello", "fr": "Bonjour"},
+			"welcome": {"de": "Willkommen", "en": "Welcome"},
+			"bye":     {"de": "", "en": "Goodbye"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	report := i18n.coverageReport(nil)
+
+	if report.TotalKeys != 3 {
+		t.Errorf("expected 3 total keys, got %d", report.TotalKeys)
+	}
+	if len(report.Languages) != 2 {
+		t.Fatalf("expected 2 languages, got %d", len(report.Languages))
+	}
+
+	de := report.Languages[0]
+	if de.Language != "de" || de.Translated != 2 || de.Missing != 1 {
+		t.Errorf("unexpected coverage for de: %+v", de)
+	}
+	if strings.Join(de.MissingKeys, ",") != "bye" {
+		t.Errorf("expected empty translation 'bye' to be missing for de, got %v", de.MissingKeys)
+	}
+
+	fr := report.Languages[1]
+	if fr.Language != "fr" || fr.Translated != 1 || fr.Missing != 2 {
+		t.Errorf("unexpected coverage for fr: %+v", fr)
+	}
+	if strings.Join(fr.MissingKeys, ",") != "bye,welcome" {
+		t.Errorf("expected missing keys 'bye,welcome' for fr, got %v", fr.MissingKeys)
+	}
+	if fr.Percent < 33.3 || fr.Percent > 33.4 {
+		t.Errorf("expected ~33.3 percent for fr, got %f", fr.Percent)
+	}
+}
+
+func TestCoverageReportDefaultsToDictionaryLanguages(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"de": "Hallo", "en": "Hello"},
+			"bye":   {"en": "Goodbye", "it": "Ciao"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	report := i18n.coverageReport(nil)
+
+	var langs []string
+	for _, cov := range report.Languages {
+		langs = append(langs, cov.Language)
+	}
+	if strings.Join(langs, ",") != "de,en,it" {
+		t.Errorf("expected languages 'de,en,it', got %v", langs)
+	}
+	if report.Languages[1].Percent != 100 {
+		t.Errorf("expected 100 percent for en, got %f", report.Languages[1].Percent)
+	}
+}
+
+func TestCoverageReportEmptyDictionary(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{},
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	report := i18n.coverageReport([]string{"fr"})
+
+	if len(report.Languages) != 1 || report.Languages[0].Percent != 100 {
+		t.Errorf("expected full coverage for empty dictionary, got %+v", report.Languages)
+	}
+}
+
+func TestAdminCoverageEndpoint(t *testing.T) {
+	dictFile := createTestDictFile(t, `{
+		"hello": {"de": "Hallo", "en": "Hello"},
+		"bye": {"en": "Goodbye"}
+	}`)
+
+	i18n := &I18n{DictFile: dictFile, RequiredLanguages: []string{"de"}}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/i18n/coverage?lang=fr&dict_file="+dictFile, nil)
+	rec := httptest.NewRecorder()
+	if err := (adminAPI{}).handleCoverage(rec, req); err != nil {
+		t.Fatalf("handleCoverage failed: %v", err)
+	}
+
+	var reports []CoverageReport
+	if err := json.NewDecoder(rec.Body).Decode(&reports); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(reports))
+	}
+	if len(reports[0].Languages) != 1 || reports[0].Languages[0].Language != "fr" {
+		t.Errorf("expected lang query parameter to override required languages, got %+v", reports[0].Languages)
+	}
+	if reports[0].Languages[0].Missing != 2 {
+		t.Errorf("expected 2 missing keys for fr, got %d", reports[0].Languages[0].Missing)
+	}
+}
+
+func TestAdminCoverageEndpointMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/i18n/coverage", nil)
+	rec := httptest.NewRecorder()
+
+	err := (adminAPI{}).handleCoverage(rec, req)
+	apiErr, ok := err.(caddy.APIError)
+	if !ok || apiErr.HTTPStatus != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 APIError, got %v", err)
+	}
+}
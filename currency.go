@@ -0,0 +1,275 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Currency display forms.
+const (
+	currencyDisplaySymbol = "symbol"
+	currencyDisplayNarrow = "narrow"
+	currencyDisplayCode   = "code"
+)
+
+// currencyPatterns holds the CLDR standard and accounting currency patterns
+// of a locale. In the patterns, ¤ stands for the currency symbol, - for the
+// minus sign and #,##0.00 for the number; a pattern without a negative
+// subpattern after ";" uses the positive one prefixed by the minus sign.
+type currencyPatterns struct {
+	standard, accounting string
+}
+
+// currencyPatternData contains the CLDR currency patterns of the bundled
+// locales for the latn numbering system. The spaces are non-breaking, as in CLDR.
+// Other locales use the patterns of their parent or, failing that, are
+// formatted entirely in English, see formatCurrency.
+var currencyPatternData = map[string]currencyPatterns{
+	"bg":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"cs":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"da":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"de":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"de-AT": {standard: "¤\u00a0#,##0.00", accounting: "¤\u00a0#,##0.00"},
+	"de-CH": {standard: "¤\u00a0#,##0.00;¤-#,##0.00", accounting: "¤\u00a0#,##0.00;¤-#,##0.00"},
+	"el":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"en":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+	"es":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"es-MX": {standard: "¤#,##0.00", accounting: "¤#,##0.00"},
+	"fi":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"fr":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤;(#,##0.00\u00a0¤)"},
+	"fr-CH": {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"hu":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"id":    {standard: "¤#,##0.00", accounting: "¤#,##0.00"},
+	"it":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"ja":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+	"ko":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+	"nb":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"nl":    {standard: "¤\u00a0#,##0.00;¤\u00a0-#,##0.00", accounting: "¤\u00a0#,##0.00;(¤\u00a0#,##0.00)"},
+	"pl":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"pt":    {standard: "¤\u00a0#,##0.00", accounting: "¤\u00a0#,##0.00"},
+	"pt-PT": {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤;(#,##0.00\u00a0¤)"},
+	"ro":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"ru":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"sk":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"sv":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"th":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+	"tr":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+	"uk":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"vi":    {standard: "#,##0.00\u00a0¤", accounting: "#,##0.00\u00a0¤"},
+	"zh":    {standard: "¤#,##0.00", accounting: "¤#,##0.00;(¤#,##0.00)"},
+}
+
+// affixes splits a currency pattern into the text before and after the
+// number, for positive or negative amounts.
+func affixes(pattern string, negative bool) (prefix, suffix string) {
+	positive, negativePattern, hasNegative := strings.Cut(pattern, ";")
+	if negative {
+		if hasNegative {
+			pattern = negativePattern
+		} else {
+			pattern = "-" + positive
+		}
+	} else {
+		pattern = positive
+	}
+
+	start := strings.IndexAny(pattern, "#0")
+	end := strings.LastIndexAny(pattern, "#0") + 1
+	return pattern[:start], pattern[end:]
+}
+
+// currencyOptions controls how an amount is formatted as currency.
+type currencyOptions struct {
+	code       string
+	display    string
+	accounting bool
+	cash       bool
+	number     string
+}
+
+// parseCurrencyOptions splits space-separated currency options: a three-letter
+// ISO 4217 code, "accounting", "cash", display=symbol|narrow|code, and number
+// options as in i18nNumber, which override the currency's digits.
+func parseCurrencyOptions(opts string) (currencyOptions, error) {
+	result := currencyOptions{display: currencyDisplaySymbol}
+	var numberOpts []string
+	for _, opt := range strings.Fields(opts) {
+		switch {
+		case opt == "accounting":
+			result.accounting = true
+		case opt == "cash":
+			result.cash = true
+		case strings.HasPrefix(opt, "display="):
+			result.display = strings.TrimPrefix(opt, "display=")
+			switch result.display {
+			case currencyDisplaySymbol, currencyDisplayNarrow, currencyDisplayCode:
+			default:
+				return result, fmt.Errorf("invalid currency option %q: must be symbol, narrow or code", opt)
+			}
+		case isCurrencyCode(opt):
+			result.code = opt
+		default:
+			numberOpts = append(numberOpts, opt)
+		}
+	}
+	result.number = strings.Join(numberOpts, " ")
+	return result, nil
+}
+
+// isCurrencyCode reports whether s looks like an ISO 4217 code, e.g. "EUR".
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// splitAmount separates an amount with a currency code, such as "12.50 EUR"
+// or "EUR 12.50", into amount and code. Other values are returned as is.
+func splitAmount(value interface{}) (interface{}, string) {
+	s, ok := value.(string)
+	if !ok {
+		return value, ""
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return value, ""
+	}
+	if isCurrencyCode(fields[0]) {
+		return fields[1], fields[0]
+	}
+	if isCurrencyCode(fields[1]) {
+		return fields[0], fields[1]
+	}
+	return value, ""
+}
+
+// formatCurrency formats amount in the currency with the ISO 4217 code for
+// lang: the currency symbol is placed and spaced according to the locale's
+// CLDR pattern, the number of fraction digits is the currency's, and with
+// the accounting option negative amounts use the accounting pattern, e.g.
+// "(€1,234.56)" in English. If code is empty, it is taken from amount, e.g.
+// "1234.56 EUR", or from opts.
+func formatCurrency(amount interface{}, code, lang, opts string) (string, error) {
+	options, err := parseCurrencyOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	amount, amountCode := splitAmount(amount)
+	switch {
+	case code != "":
+	case amountCode != "":
+		code = amountCode
+	case options.code != "":
+		code = options.code
+	default:
+		return "", fmt.Errorf("no currency code given")
+	}
+
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid currency code %q: %v", code, err)
+	}
+	d, err := toDecimal(amount)
+	if err != nil {
+		return "", err
+	}
+
+	kind := currency.Standard
+	if options.cash {
+		kind = currency.Cash
+	}
+	scale, increment := kind.Rounding(unit)
+	numberOpts, err := parseNumberOptions(options.number, numberOptions{
+		minFraction: scale,
+		maxFraction: scale,
+		rounding:    roundHalfEven,
+		grouping:    true,
+	})
+	if err != nil {
+		return "", err
+	}
+	if increment > 1 && numberOpts.maxFraction == scale {
+		// Cash rounding, e.g. to 0.05 CHF
+		step := decimal.New(int64(increment), -int32(scale))
+		d = roundDecimal(d.Div(step), 0, numberOpts.rounding).Mul(step)
+	}
+
+	patterns, ok := findLocale(currencyPatternData, lang)
+	if !ok {
+		// A locale without bundled placement patterns is formatted
+		// entirely in English, including the symbol and the digits,
+		// e.g. "€1,234.56" rather than "€١٬٢٣٤٫٥٦".
+		lang = "en"
+	}
+	sym := symbolsFor(lang)
+	negative := roundDecimal(d, numberOpts.maxFraction, numberOpts.rounding).IsNegative()
+	number := sym.formatDecimal(d, numberOpts)
+
+	pattern := patterns.standard
+	if options.accounting {
+		pattern = patterns.accounting
+	}
+	prefix, suffix := affixes(pattern, negative)
+	symbol := currencySymbol(unit, lang, options.display)
+
+	return expandAffix(prefix, symbol, sym, true) + number + expandAffix(suffix, symbol, sym, false), nil
+}
+
+// currencySymbol returns the symbol of unit in lang from the CLDR data of
+// golang.org/x/text, e.g. "€", "CA$" or "CHF".
+func currencySymbol(unit currency.Unit, lang, display string) string {
+	p := message.NewPrinter(language.Make(lang))
+	switch display {
+	case currencyDisplayNarrow:
+		return p.Sprint(currency.NarrowSymbol(unit))
+	case currencyDisplayCode:
+		return unit.String()
+	}
+	return p.Sprint(currency.Symbol(unit))
+}
+
+// expandAffix replaces ¤ and - in a pattern affix with the currency symbol and
+// the locale's minus sign. Following the CLDR currency spacing rules, a
+// non-breaking space separates a symbol ending (or, after the number,
+// starting) with a letter from the adjacent number, e.g. "CHF 5.00".
+func expandAffix(affix, symbol string, sym *numberSymbols, beforeNumber bool) string {
+	if strings.HasSuffix(affix, "¤") && beforeNumber {
+		if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+			affix += "\u00a0"
+		}
+	}
+	if strings.HasPrefix(affix, "¤") && !beforeNumber {
+		if r, _ := utf8.DecodeRuneInString(symbol); unicode.IsLetter(r) {
+			affix = "\u00a0" + affix
+		}
+	}
+	affix = strings.ReplaceAll(affix, "-", sym.minusPrefix)
+	return strings.ReplaceAll(affix, "¤", symbol)
+}
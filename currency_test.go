@@ -0,0 +1,139 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		amount   interface{}
+		code     string
+		lang     string
+		opts     string
+		expected string
+	}{
+		{1234.56, "EUR", "de", "", "1.234,56\u00a0€"},
+		{1234.56, "EUR", "en", "", "€1,234.56"},
+		{"1234.56", "EUR", "fr", "", "1\u00a0234,56\u00a0€"},
+		{1234.56, "EUR", "nl", "", "€\u00a01.234,56"},
+		{-1234.56, "EUR", "nl", "", "€\u00a0-1.234,56"},
+		{1234.56, "USD", "fr", "", "1\u00a0234,56\u00a0$US"},
+		{1234.56, "BRL", "pt-BR", "", "R$\u00a01.234,56"},
+		{1234.56, "EUR", "de-AT", "", "€\u00a01\u00a0234,56"},
+		{-5, "CHF", "de-CH", "", "CHF-5.00"},
+		{5, "CHF", "en", "", "CHF\u00a05.00"},
+		{1234.6, "JPY", "en", "", "¥1,235"},
+		{1234.5, "JPY", "en", "", "¥1,234"},
+		{1.2345, "KWD", "en", "", "KWD\u00a01.234"},
+		{-1234.56, "EUR", "en", "", "-€1,234.56"},
+		{-1234.56, "EUR", "en", "accounting", "(€1,234.56)"},
+		{-1234.56, "EUR", "de", "accounting", "-1.234,56\u00a0€"},
+		{-1234.56, "EUR", "fr", "accounting", "(1\u00a0234,56\u00a0€)"},
+		{1234.56, "EUR", "en", "accounting", "€1,234.56"},
+		{"3.57", "CHF", "de-CH", "cash", "CHF\u00a03.55"},
+		{"3.58", "CHF", "de-CH", "cash", "CHF\u00a03.60"},
+		{1234.56, "EUR", "de", "display=code", "1.234,56\u00a0EUR"},
+		{1234.56, "CAD", "en", "display=narrow", "$1,234.56"},
+		{1234.5, "EUR", "en", "max=0", "€1,234"},
+		{"1234.56 EUR", "", "de", "", "1.234,56\u00a0€"},
+		{"USD 10", "", "en", "", "$10.00"},
+		{10, "", "en", "GBP", "£10.00"},
+		{1234.56, "EUR", "ru", "", "1\u00a0234,56\u00a0€"},
+		{1234.56, "EUR", "sv", "", "1\u00a0234,56\u00a0€"},
+		{1234.56, "EUR", "pl", "", "1234,56\u00a0€"},
+		{-1234.56, "JPY", "ja", "accounting", "(￥1,235)"},
+		// Languages without bundled patterns are formatted entirely in
+		// English, including the symbol and the digits
+		{1234.56, "EUR", "sw", "", "€1,234.56"},
+		{1234.56, "EUR", "ca", "", "€1,234.56"},
+		{1234.56, "EUR", "ar", "", "€1,234.56"},
+		{-1234.56, "USD", "he", "accounting", "($1,234.56)"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatCurrency(tt.amount, tt.code, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatCurrency(%v, %q, %q, %q): unexpected error: %v", tt.amount, tt.code, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatCurrency(%v, %q, %q, %q): expected %q, got %q", tt.amount, tt.code, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatCurrencyErrors(t *testing.T) {
+	tests := []struct {
+		amount interface{}
+		code   string
+		opts   string
+	}{
+		{10, "", ""},
+		{10, "XYZ", ""},
+		{"ten", "EUR", ""},
+		{10, "EUR", "display=name"},
+		{10, "EUR", "max=x"},
+	}
+
+	for _, tt := range tests {
+		if _, err := formatCurrency(tt.amount, tt.code, "en", tt.opts); err == nil {
+			t.Errorf("formatCurrency(%v, %q, %q): expected error, got nil", tt.amount, tt.code, tt.opts)
+		}
+	}
+}
+
+func TestCurrencyPlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"finance.balance": {"de": "Kontostand: {0, currency, EUR}", "en": "Balance: {0, currency, EUR accounting}"},
+			"finance.amount":  {"de": "Betrag: {0, currency}"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	tests := []struct {
+		key      string
+		lang     string
+		arg      interface{}
+		expected string
+	}{
+		{"finance.balance", "de", "1234.56", "Kontostand: 1.234,56\u00a0€"},
+		{"finance.balance", "en", -1234.56, "Balance: (€1,234.56)"},
+		{"finance.amount", "de", "99.5 USD", "Betrag: 99,50\u00a0$"},
+		{"finance.amount", "de", "99.5", "Betrag: 99.5"},
+	}
+
+	for _, tt := range tests {
+		result := i18n.translate(nil, tt.key, tt.lang, []interface{}{tt.arg})
+		if result != tt.expected {
+			t.Errorf("%s/%s: expected %q, got %q", tt.key, tt.lang, tt.expected, result)
+		}
+	}
+}
+
+func TestI18nCurrencyFunction(t *testing.T) {
+	i18n := &I18n{}
+	currencyFunc := i18n.CustomTemplateFunctions()["i18nCurrency"].(func(interface{}, string, string, ...string) (string, error))
+
+	if result, err := currencyFunc(-7.5, "USD", "en", "accounting"); err != nil || result != "($7.50)" {
+		t.Errorf("expected '($7.50)', got %q (%v)", result, err)
+	}
+}
@@ -0,0 +1,446 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date and time styles as defined by CLDR.
+const (
+	styleShort  = "short"
+	styleMedium = "medium"
+	styleLong   = "long"
+	styleFull   = "full"
+)
+
+// calendarData holds the CLDR Gregorian calendar data of a locale.
+type calendarData struct {
+	months, monthsAbbr [12]string
+	days, daysAbbr     [7]string // starting with Sunday
+	am, pm             string
+
+	// dateFormats and timeFormats map styles to CLDR date format patterns.
+	dateFormats, timeFormats map[string]string
+
+	// gmtFormat is the localized GMT format, e.g. "GMT{0}", gmtZeroFormat
+	// its form for a zero offset, and hourFormat the positive and negative
+	// offset patterns, e.g. "+HH:mm;-HH:mm".
+	gmtFormat, gmtZeroFormat, hourFormat string
+
+	// zones maps metazones and time zones to their names, see zoneName.
+	zones map[string]zoneNames
+}
+
+// Time formats shared by most of the bundled locales.
+var timeFormats24h = map[string]string{
+	styleFull:   "HH:mm:ss zzzz",
+	styleLong:   "HH:mm:ss z",
+	styleMedium: "HH:mm:ss",
+	styleShort:  "HH:mm",
+}
+
+// calendars contains the calendar data of the bundled locales, from the CLDR
+// Gregorian calendar in the format context.
+var calendars = map[string]*calendarData{
+	"de": {
+		months:     [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		daysAbbr:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		am:         "AM",
+		pm:         "PM",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE, d. MMMM y",
+			styleLong:   "d. MMMM y",
+			styleMedium: "dd.MM.y",
+			styleShort:  "dd.MM.yy",
+		},
+		timeFormats:   timeFormats24h,
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["de"],
+	},
+	"en": {
+		months:     [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsAbbr: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		daysAbbr:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:         "AM",
+		pm:         "PM",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE, MMMM d, y",
+			styleLong:   "MMMM d, y",
+			styleMedium: "MMM d, y",
+			styleShort:  "M/d/yy",
+		},
+		timeFormats: map[string]string{
+			styleFull:   "h:mm:ss a zzzz",
+			styleLong:   "h:mm:ss a z",
+			styleMedium: "h:mm:ss a",
+			styleShort:  "h:mm a",
+		},
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["en"],
+	},
+	"es": {
+		months:     [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		daysAbbr:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		am:         "a. m.",
+		pm:         "p. m.",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE, d 'de' MMMM 'de' y",
+			styleLong:   "d 'de' MMMM 'de' y",
+			styleMedium: "d MMM y",
+			styleShort:  "d/M/yy",
+		},
+		timeFormats: map[string]string{
+			styleFull:   "H:mm:ss (zzzz)",
+			styleLong:   "H:mm:ss z",
+			styleMedium: "H:mm:ss",
+			styleShort:  "H:mm",
+		},
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["es"],
+	},
+	"fr": {
+		months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		daysAbbr:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		am:         "AM",
+		pm:         "PM",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE d MMMM y",
+			styleLong:   "d MMMM y",
+			styleMedium: "d MMM y",
+			styleShort:  "dd/MM/y",
+		},
+		timeFormats:   timeFormats24h,
+		gmtFormat:     "UTC{0}",
+		gmtZeroFormat: "UTC",
+		hourFormat:    "+HH:mm;\u2212HH:mm",
+		zones:         zoneNameData["fr"],
+	},
+	"it": {
+		months:     [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:       [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		daysAbbr:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		am:         "AM",
+		pm:         "PM",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE d MMMM y",
+			styleLong:   "d MMMM y",
+			styleMedium: "d MMM y",
+			styleShort:  "dd/MM/yy",
+		},
+		timeFormats:   timeFormats24h,
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["it"],
+	},
+	"nl": {
+		months:     [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		monthsAbbr: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:       [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		daysAbbr:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		am:         "a.m.",
+		pm:         "p.m.",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE d MMMM y",
+			styleLong:   "d MMMM y",
+			styleMedium: "d MMM y",
+			styleShort:  "dd-MM-y",
+		},
+		timeFormats:   timeFormats24h,
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["nl"],
+	},
+	"pt": {
+		months:     [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		days:       [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		daysAbbr:   [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		am:         "AM",
+		pm:         "PM",
+		dateFormats: map[string]string{
+			styleFull:   "EEEE, d 'de' MMMM 'de' y",
+			styleLong:   "d 'de' MMMM 'de' y",
+			styleMedium: "d 'de' MMM 'de' y",
+			styleShort:  "dd/MM/y",
+		},
+		timeFormats:   timeFormats24h,
+		gmtFormat:     "GMT{0}",
+		gmtZeroFormat: "GMT",
+		hourFormat:    "+HH:mm;-HH:mm",
+		zones:         zoneNameData["pt"],
+	},
+}
+
+func init() {
+	// Regional variants that differ from their base language.
+	enGB := *calendars["en"]
+	enGB.dateFormats = map[string]string{
+		styleFull:   "EEEE d MMMM y",
+		styleLong:   "d MMMM y",
+		styleMedium: "d MMM y",
+		styleShort:  "dd/MM/y",
+	}
+	enGB.timeFormats = timeFormats24h
+	enGB.am, enGB.pm = "am", "pm"
+	enGB.zones = zoneNameData["en-GB"]
+	calendars["en-GB"] = &enGB
+
+	deAT := *calendars["de"]
+	deAT.months[0] = "Jänner"
+	deAT.monthsAbbr[0] = "Jän."
+	calendars["de-AT"] = &deAT
+}
+
+// dateTimeOptions controls how a date or time is formatted.
+type dateTimeOptions struct {
+	style    string
+	location *time.Location
+
+	// fallback formats languages without calendar data in English instead
+	// of failing.
+	fallback bool
+}
+
+// parseDateTimeOptions parses space-separated options: a style (short,
+// medium, long or full), an IANA time zone such as "Europe/Berlin",
+// optionally written as tz=Europe/Berlin, and fallback=en. The style
+// defaults to medium.
+func parseDateTimeOptions(opts string) (dateTimeOptions, error) {
+	result := dateTimeOptions{style: styleMedium}
+	for _, opt := range strings.Fields(opts) {
+		switch opt {
+		case styleShort, styleMedium, styleLong, styleFull:
+			result.style = opt
+			continue
+		case "fallback=en":
+			result.fallback = true
+			continue
+		}
+		loc, err := time.LoadLocation(strings.TrimPrefix(opt, "tz="))
+		if err != nil {
+			return result, fmt.Errorf("invalid date option %q: not a style or time zone", opt)
+		}
+		result.location = loc
+	}
+	return result, nil
+}
+
+// toTime converts a template value to a time: a time.Time, a Unix timestamp
+// in seconds as a number or string, or an RFC 3339 string such as
+// "2025-03-14T09:30:00+01:00" or "2025-03-14". Unix timestamps and dates
+// without time zone are in UTC.
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v != nil {
+			return *v, nil
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		sec, err := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+		if err == nil {
+			return time.Unix(sec, 0).UTC(), nil
+		}
+	case float32, float64:
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			sec, frac := math.Modf(f)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		}
+	case string:
+		s := strings.TrimSpace(v)
+		if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.Unix(sec, 0).UTC(), nil
+		}
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, s); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as RFC 3339 time or Unix timestamp", v)
+	}
+	return time.Time{}, fmt.Errorf("cannot format %T as a time", value)
+}
+
+// formatDate formats the date of value in lang with the CLDR date format of
+// the style given in opts.
+func formatDate(value interface{}, lang, opts string) (string, error) {
+	return formatDateTime(value, lang, opts, func(cal *calendarData, style string) string {
+		return cal.dateFormats[style]
+	})
+}
+
+// formatTime formats the time of day of value in lang with the CLDR time
+// format of the style given in opts.
+func formatTime(value interface{}, lang, opts string) (string, error) {
+	return formatDateTime(value, lang, opts, func(cal *calendarData, style string) string {
+		return cal.timeFormats[style]
+	})
+}
+
+// formatDateTime converts value to a time in the time zone of opts and
+// formats it with the pattern selected by pattern. Languages without
+// bundled calendar data are an error unless opts contain fallback=en.
+func formatDateTime(value interface{}, lang, opts string, pattern func(*calendarData, string) string) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	options, err := parseDateTimeOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	if options.location != nil {
+		t = t.In(options.location)
+	}
+	cal, ok := findLocale(calendars, lang)
+	if !ok {
+		if !options.fallback {
+			return "", fmt.Errorf("no calendar data for language %q; add the option fallback=en to format it in English", lang)
+		}
+		// The whole output is English, including the digits, rather than
+		// English words with native digits.
+		lang = "en"
+	}
+	return formatDatePattern(t, pattern(cal, options.style), cal, symbolsFor(lang)), nil
+}
+
+// formatDatePattern formats t with a CLDR date format pattern. Text in single
+// quotes is literal, and two single quotes are one quote. Supported fields are
+// y, M, L, d, E, a, h, H, m, s and z; other letters are copied as is.
+func formatDatePattern(t time.Time, pattern string, cal *calendarData, sym *numberSymbols) string {
+	var sb strings.Builder
+	runes := []rune(pattern)
+	for idx := 0; idx < len(runes); {
+		c := runes[idx]
+
+		if c == '\'' {
+			if idx+1 < len(runes) && runes[idx+1] == '\'' {
+				sb.WriteRune('\'')
+				idx += 2
+				continue
+			}
+			end := idx + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			sb.WriteString(string(runes[idx+1 : end]))
+			idx = end + 1
+			continue
+		}
+
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			sb.WriteRune(c)
+			idx++
+			continue
+		}
+
+		count := 1
+		for idx+count < len(runes) && runes[idx+count] == c {
+			count++
+		}
+		idx += count
+
+		switch c {
+		case 'y':
+			if count == 2 {
+				sb.WriteString(sym.digitString(t.Year()%100, 2))
+			} else {
+				sb.WriteString(sym.digitString(t.Year(), count))
+			}
+		case 'M', 'L':
+			switch {
+			case count >= 4:
+				sb.WriteString(cal.months[t.Month()-1])
+			case count == 3:
+				sb.WriteString(cal.monthsAbbr[t.Month()-1])
+			default:
+				sb.WriteString(sym.digitString(int(t.Month()), count))
+			}
+		case 'd':
+			sb.WriteString(sym.digitString(t.Day(), count))
+		case 'E':
+			if count >= 4 {
+				sb.WriteString(cal.days[t.Weekday()])
+			} else {
+				sb.WriteString(cal.daysAbbr[t.Weekday()])
+			}
+		case 'a':
+			if t.Hour() < 12 {
+				sb.WriteString(cal.am)
+			} else {
+				sb.WriteString(cal.pm)
+			}
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			sb.WriteString(sym.digitString(hour, count))
+		case 'H':
+			sb.WriteString(sym.digitString(t.Hour(), count))
+		case 'm':
+			sb.WriteString(sym.digitString(t.Minute(), count))
+		case 's':
+			sb.WriteString(sym.digitString(t.Second(), count))
+		case 'z':
+			sb.WriteString(zoneName(t, cal, sym, count >= 4))
+		default:
+			sb.WriteString(strings.Repeat(string(c), count))
+		}
+	}
+	return sb.String()
+}
+
+// digitString formats a non-negative integer with at least width digits in
+// the locale's digits.
+func (sym *numberSymbols) digitString(n, width int) string {
+	str := strconv.Itoa(n)
+	if len(str) < width {
+		str = strings.Repeat("0", width-len(str)) + str
+	}
+	var sb strings.Builder
+	for _, c := range str {
+		if c >= '0' && c <= '9' {
+			sb.WriteString(sym.digits[c-'0'])
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatDate(t *testing.T) {
+	// Friday, 14 March 2025, 09:05:07 UTC
+	ts := time.Date(2025, time.March, 14, 9, 5, 7, 0, time.UTC)
+
+	tests := []struct {
+		lang     string
+		opts     string
+		expected string
+	}{
+		{"en", "", "Mar 14, 2025"},
+		{"en", "short", "3/14/25"},
+		{"en", "long", "March 14, 2025"},
+		{"en", "full", "Friday, March 14, 2025"},
+		{"en-GB", "short", "14/03/2025"},
+		{"en-GB", "full", "Friday 14 March 2025"},
+		{"de", "", "14.03.2025"},
+		{"de", "short", "14.03.25"},
+		{"de", "long", "14. März 2025"},
+		{"de", "full", "Freitag, 14. März 2025"},
+		{"de-AT", "long", "14. März 2025"},
+		{"fr", "full", "vendredi 14 mars 2025"},
+		{"fr", "short", "14/03/2025"},
+		{"es", "long", "14 de marzo de 2025"},
+		{"it", "medium", "14 mar 2025"},
+		{"nl", "short", "14-03-2025"},
+		{"pt-BR", "full", "sexta-feira, 14 de março de 2025"},
+		{"de", "long Pacific/Honolulu", "13. März 2025"},
+		{"de", "long tz=America/New_York", "14. März 2025"},
+		// Languages without calendar data are formatted entirely in English
+		// with fallback=en
+		{"ar", "long fallback=en", "March 14, 2025"},
+		{"ja", "full fallback=en", "Friday, March 14, 2025"},
+		{"ru", "short fallback=en", "3/14/25"},
+		{"de", "long fallback=en", "14. März 2025"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatDate(ts, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatDate(%q, %q): unexpected error: %v", tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatDate(%q, %q): expected %q, got %q", tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+
+	jan := time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+	if result, _ := formatDate(jan, "de-AT", "long"); result != "2. Jänner 2025" {
+		t.Errorf("expected '2. Jänner 2025' for de-AT, got %q", result)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	ts := time.Date(2025, time.March, 14, 21, 5, 7, 0, time.UTC)
+
+	tests := []struct {
+		lang     string
+		opts     string
+		expected string
+	}{
+		{"en", "", "9:05:07 PM"},
+		{"en", "short", "9:05 PM"},
+		{"en", "full", "9:05:07 PM Coordinated Universal Time"},
+		{"en", "long", "9:05:07 PM UTC"},
+		{"en-GB", "short", "21:05"},
+		{"de", "short", "21:05"},
+		{"de", "short Europe/Berlin", "22:05"},
+		{"es", "short", "21:05"},
+		{"ar", "short fallback=en", "9:05 PM"},
+		{"zh", "short fallback=en", "9:05 PM"},
+
+		// CLDR metazone names, with the localized GMT format for zones
+		// and locales without names
+		{"de", "long Europe/Berlin", "22:05:07 MEZ"},
+		{"de", "full Europe/Berlin", "22:05:07 Mitteleuropäische Normalzeit"},
+		{"en", "long Europe/Berlin", "10:05:07 PM GMT+1"},
+		{"en", "full Europe/Berlin", "10:05:07 PM Central European Standard Time"},
+		{"en-GB", "long Europe/Berlin", "22:05:07 CET"},
+		{"es", "full Europe/Madrid", "22:05:07 (hora estándar de Europa central)"},
+		{"en", "long America/New_York", "5:05:07 PM EDT"},
+		{"fr", "full America/New_York", "17:05:07 heure d’été de l’Est"},
+		{"fr", "long America/New_York", "17:05:07 HEE"},
+		{"it", "long America/New_York", "17:05:07 GMT-4"},
+		{"fr", "full America/Caracas", "17:05:07 UTC\u221204:00"},
+		{"en", "long Asia/Kathmandu", "2:50:07 AM GMT+5:45"},
+		{"nl", "full Asia/Kathmandu", "02:50:07 GMT+05:45"},
+		{"pt", "full Asia/Tokyo", "06:05:07 Horário Padrão do Japão"},
+		{"en", "full tz=Europe/London", "9:05:07 PM Greenwich Mean Time"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatTime(ts, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatTime(%q, %q): unexpected error: %v", tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatTime(%q, %q): expected %q, got %q", tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestToTime(t *testing.T) {
+	expected := time.Date(2025, time.March, 14, 9, 5, 7, 0, time.UTC)
+
+	tests := []interface{}{
+		expected,
+		&expected,
+		expected.Unix(),
+		int(expected.Unix()),
+		float64(expected.Unix()),
+		"1741943107",
+		"2025-03-14T09:05:07Z",
+		"2025-03-14T10:05:07+01:00",
+	}
+	for _, value := range tests {
+		result, err := toTime(value)
+		if err != nil {
+			t.Errorf("toTime(%v): unexpected error: %v", value, err)
+			continue
+		}
+		if !result.Equal(expected) {
+			t.Errorf("toTime(%v): expected %v, got %v", value, expected, result)
+		}
+	}
+
+	if result, err := toTime("2025-03-14"); err != nil || !result.Equal(time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected date-only string to parse as UTC midnight, got %v (%v)", result, err)
+	}
+	for _, value := range []interface{}{"yesterday", []int{1}, (*time.Time)(nil)} {
+		if _, err := toTime(value); err == nil {
+			t.Errorf("toTime(%v): expected error, got nil", value)
+		}
+	}
+}
+
+func TestFormatDateInvalidOptions(t *testing.T) {
+	if _, err := formatDate(0, "en", "tiny"); err == nil {
+		t.Error("expected error for invalid option, got nil")
+	}
+}
+
+func TestFormatDateWithoutCalendarData(t *testing.T) {
+	for _, lang := range []string{"ar", "ja", "pl", "ru", "zh", "ar-XB"} {
+		if _, err := formatDate(0, lang, "long"); err == nil {
+			t.Errorf("formatDate(%q): expected error, got nil", lang)
+		}
+		if _, err := formatTime(0, lang, "short"); err == nil {
+			t.Errorf("formatTime(%q): expected error, got nil", lang)
+		}
+	}
+}
+
+func TestZoneNamesSummerTime(t *testing.T) {
+	ts := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lang     string
+		opts     string
+		expected string
+	}{
+		{"de", "long Europe/Berlin", "14:00:00 MESZ"},
+		{"de", "full Europe/Vienna", "14:00:00 Mitteleuropäische Sommerzeit"},
+		{"en", "full Europe/London", "1:00:00 PM British Summer Time"},
+		{"en", "long Europe/London", "1:00:00 PM GMT+1"},
+		{"en-GB", "long Europe/London", "13:00:00 BST"},
+		{"en", "long America/Los_Angeles", "5:00:00 AM PDT"},
+		{"en", "long Pacific/Honolulu", "2:00:00 AM HST"},
+	}
+	for _, tt := range tests {
+		result, err := formatTime(ts, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatTime(%q, %q): unexpected error: %v", tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatTime(%q, %q): expected %q, got %q", tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestDatePlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"tx.booked": {
+				"de": "Gebucht am {0, date, long} um {0, time, short Europe/Berlin}",
+				"en": "Booked on {0, date} at {0, time, short Europe/Berlin}",
+			},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	arg := []interface{}{"2025-03-14T09:05:07Z"}
+	if result := i18n.translate(nil, "tx.booked", "de", arg); result != "Gebucht am 14. März 2025 um 10:05" {
+		t.Errorf("unexpected German result %q", result)
+	}
+	if result := i18n.translate(nil, "tx.booked", "en", arg); result != "Booked on Mar 14, 2025 at 10:05 AM" {
+		t.Errorf("unexpected English result %q", result)
+	}
+}
+
+func TestI18nDateAndTimeFunctions(t *testing.T) {
+	funcMap := (&I18n{}).CustomTemplateFunctions()
+	dateFunc := funcMap["i18nDate"].(func(interface{}, string, ...string) (string, error))
+	timeFunc := funcMap["i18nTime"].(func(interface{}, string, ...string) (string, error))
+
+	if result, err := dateFunc(1741943107, "de", "full", "UTC"); err != nil || result != "Freitag, 14. März 2025" {
+		t.Errorf("expected 'Freitag, 14. März 2025', got %q (%v)", result, err)
+	}
+	if result, err := timeFunc(1741943107, "en", "short"); err != nil || result != "9:05 AM" {
+		t.Errorf("expected '9:05 AM', got %q (%v)", result, err)
+	}
+}
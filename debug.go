@@ -0,0 +1,129 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// Debug key modes.
+const (
+	// debugModeWrap prefixes the translation with its key, e.g. "[finance.account] Konto".
+	debugModeWrap = "wrap"
+
+	// debugModeKey replaces the translation with its key.
+	debugModeKey = "key"
+
+	// debugModeEdit wraps the translation in a span carrying its key and
+	// language, for use with the in-context editor overlay.
+	debugModeEdit = "edit"
+)
+
+// defaultDebugQueryParam is the query parameter that toggles the debug mode
+// if no trigger is configured.
+const defaultDebugQueryParam = "i18n_debug"
+
+// DebugKeysConfig allows showing translation keys instead of, or next to, the
+// translated text. The mode is turned on per request by a query parameter,
+// cookie or header, but only for request-aware lookups such as
+// i18nTranslateCtx, since plain i18nTranslate has no access to the request.
+//
+// A trigger value of "wrap", "key" or "edit" selects that mode; "1", "true"
+// and "on" select the configured Mode. Any other value leaves the debug mode off.
+type DebugKeysConfig struct {
+	// QueryParam is the query parameter that turns on the debug mode.
+	// Defaults to "i18n_debug" if no trigger is configured.
+	QueryParam string `json:"query_param,omitempty"`
+
+	// Cookie is the cookie that turns on the debug mode.
+	Cookie string `json:"cookie,omitempty"`
+
+	// Header is the request header that turns on the debug mode.
+	Header string `json:"header,omitempty"`
+
+	// Mode is the default mode: "wrap" shows "[key] translation",
+	// "key" shows only the key, and "edit" emits a
+	// <span data-i18n-key="..." data-i18n-lang="..."> element for the
+	// in-context editor. Defaults to "wrap".
+	Mode string `json:"mode,omitempty"`
+}
+
+// validate checks the configured mode.
+func (c *DebugKeysConfig) validate() error {
+	switch c.Mode {
+	case "", debugModeWrap, debugModeKey, debugModeEdit:
+		return nil
+	}
+	return fmt.Errorf("invalid debug_keys mode %q: must be %q, %q or %q", c.Mode, debugModeWrap, debugModeKey, debugModeEdit)
+}
+
+// mode returns the debug mode requested by r, or an empty string if the debug
+// mode is off. A nil *DebugKeysConfig means the debug mode is not allowed.
+func (c *DebugKeysConfig) mode(r *http.Request) string {
+	if c == nil || r == nil {
+		return ""
+	}
+
+	queryParam := c.QueryParam
+	if queryParam == "" && c.Cookie == "" && c.Header == "" {
+		queryParam = defaultDebugQueryParam
+	}
+
+	var value string
+	if queryParam != "" {
+		value = r.URL.Query().Get(queryParam)
+	}
+	if value == "" && c.Cookie != "" {
+		if cookie, err := r.Cookie(c.Cookie); err == nil {
+			value = cookie.Value
+		}
+	}
+	if value == "" && c.Header != "" {
+		value = r.Header.Get(c.Header)
+	}
+
+	switch strings.ToLower(value) {
+	case debugModeWrap:
+		return debugModeWrap
+	case debugModeKey:
+		return debugModeKey
+	case debugModeEdit:
+		return debugModeEdit
+	case "1", "true", "on":
+		if c.Mode == "" {
+			return debugModeWrap
+		}
+		return c.Mode
+	}
+	return ""
+}
+
+// decorate applies the debug mode requested by r to the translation of key.
+// lang is the language edits of the translation are written to: the
+// requested language, or the source language for pseudo-locales.
+func (c *DebugKeysConfig) decorate(r *http.Request, key, lang, val string) string {
+	switch c.mode(r) {
+	case debugModeWrap:
+		return "[" + key + "] " + val
+	case debugModeKey:
+		return key
+	case debugModeEdit:
+		return `<span data-i18n-key="` + html.EscapeString(key) + `" data-i18n-lang="` + html.EscapeString(lang) + `">` + val + `</span>`
+	}
+	return val
+}
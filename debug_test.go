@@ -0,0 +1,152 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDebugKeysModes(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"finance.account": {"de": "Konto", "en": "Account"},
+		},
+		DebugKeys: &DebugKeysConfig{Cookie: "i18n_keys", Header: "X-I18n-Keys"},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	translateFunc := funcMap["i18nTranslateCtx"].(func(*templates.TemplateContext, string, string, ...interface{}) (string, error))
+
+	tests := []struct {
+		name     string
+		setup    func(r *http.Request)
+		expected string
+	}{
+		{"off", func(r *http.Request) {}, "Konto"},
+		{"cookie true", func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "i18n_keys", Value: "true"}) }, "[finance.account] Konto"},
+		{"header key", func(r *http.Request) { r.Header.Set("X-I18n-Keys", "key") }, "finance.account"},
+		{"header off", func(r *http.Request) { r.Header.Set("X-I18n-Keys", "0") }, "Konto"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		tt.setup(r)
+		result, err := translateFunc(&templates.TemplateContext{Req: r}, "finance.account", "de")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestDebugKeysPlainTranslate(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"finance.account": {"de": "Konto", "en": "Account"},
+		},
+		DebugKeys: &DebugKeysConfig{Mode: debugModeEdit},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	// i18nTranslate has no request, so debug keys never apply to it
+	translateFunc := i18n.CustomTemplateFunctions()["i18nTranslate"].(func(string, string, ...interface{}) (string, error))
+	if result, err := translateFunc("finance.account", "de"); err != nil || result != "Konto" {
+		t.Errorf("expected 'Konto', got %q (%v)", result, err)
+	}
+}
+
+func TestDebugKeysDefaultQueryParam(t *testing.T) {
+	c := &DebugKeysConfig{Mode: debugModeKey}
+
+	r := httptest.NewRequest(http.MethodGet, "/?i18n_debug=1", nil)
+	if mode := c.mode(r); mode != debugModeKey {
+		t.Errorf("expected configured mode %q, got %q", debugModeKey, mode)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?i18n_debug=wrap", nil)
+	if mode := c.mode(r); mode != debugModeWrap {
+		t.Errorf("expected requested mode %q, got %q", debugModeWrap, mode)
+	}
+}
+
+func TestDebugKeysNotAllowed(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"en": "Hello"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/?i18n_debug=1", nil)
+	if result := i18n.translate(r, "hello", "en", nil); result != "Hello" {
+		t.Errorf("expected debug mode to be unavailable without config, got %q", result)
+	}
+}
+
+func TestDebugKeysValidate(t *testing.T) {
+	if err := (&DebugKeysConfig{Mode: "verbose"}).validate(); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+	if err := (&DebugKeysConfig{}).validate(); err != nil {
+		t.Errorf("expected empty mode to be valid, got %v", err)
+	}
+}
+
+func TestDebugKeysEditMarkup(t *testing.T) {
+	c := &DebugKeysConfig{Mode: debugModeEdit}
+	r := httptest.NewRequest(http.MethodGet, "/?i18n_debug=1", nil)
+
+	result := c.decorate(r, `key"<`, "de", "Konto")
+	expected := `<span data-i18n-key="key&#34;&lt;" data-i18n-lang="de">Konto</span>`
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestDebugKeysEditMarkupPseudoLocale(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"de": "Hallo", "en": "Hello"},
+		},
+		Pseudo:    &PseudoConfig{Expansion: -1},
+		DebugKeys: &DebugKeysConfig{Mode: debugModeEdit},
+	}
+	i18n.mu = new(sync.RWMutex)
+	r := httptest.NewRequest(http.MethodGet, "/?i18n_debug=1", nil)
+
+	// Edits of pseudo-localized text are written to the source language
+	tests := map[string]string{
+		pseudoAccented: `<span data-i18n-key="hello" data-i18n-lang="en">[Ĥéļļö]</span>`,
+		pseudoBidi:     `<span data-i18n-key="hello" data-i18n-lang="en">` + pseudoMirror("Hello") + `</span>`,
+		"de":           `<span data-i18n-key="hello" data-i18n-lang="de">Hallo</span>`,
+	}
+	for lang, expected := range tests {
+		if result := i18n.translate(r, "hello", lang, nil); result != expected {
+			t.Errorf("%s: expected %q, got %q", lang, expected, result)
+		}
+	}
+}
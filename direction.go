@@ -0,0 +1,172 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Text directions as used in the HTML dir attribute.
+const (
+	dirLTR = "ltr"
+	dirRTL = "rtl"
+)
+
+// Unicode bidi isolates, see https://www.w3.org/International/questions/qa-bidi-unicode-controls
+const (
+	fsi = "\u2068" // FIRST STRONG ISOLATE
+	pdi = "\u2069" // POP DIRECTIONAL ISOLATE
+)
+
+// Bidi isolation modes for interpolated arguments.
+const (
+	// bidiIsolationAuto isolates arguments in right-to-left translations.
+	bidiIsolationAuto = "auto"
+
+	// bidiIsolationAlways isolates arguments in all translations.
+	bidiIsolationAlways = "always"
+
+	// bidiIsolationNever leaves arguments as they are. It is the default,
+	// so that existing output doesn't change.
+	bidiIsolationNever = "never"
+)
+
+// scriptDirections caches the direction of each script, keyed by
+// language.Script.
+var scriptDirections sync.Map
+
+// unicodeScriptNames maps the normalized names of the scripts known to the
+// unicode package to their names in unicode.Scripts.
+var unicodeScriptNames = sync.OnceValue(func() map[string]string {
+	names := make(map[string]string, len(unicode.Scripts))
+	for name := range unicode.Scripts {
+		names[normalizeScriptName(name)] = name
+	}
+	return names
+})
+
+// languageDirection returns "rtl" if lang is written in a right-to-left
+// script and "ltr" otherwise. The script is the one given in lang or the
+// likely script for the language according to CLDR, e.g. Arab for "ar" or
+// "ur" and Latn for "az".
+func languageDirection(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return dirLTR
+	}
+	script, conf := tag.Script()
+	if conf == language.No {
+		return dirLTR
+	}
+	return scriptDirection(script)
+}
+
+// scriptDirection returns the direction of script. It is derived from the
+// Unicode bidi classes of the letters of the script: scripts whose letters
+// are mostly right-to-left (R or AL) are "rtl". Scripts the unicode package
+// doesn't know, such as Jpan or Hans, are "ltr".
+func scriptDirection(script language.Script) string {
+	if dir, ok := scriptDirections.Load(script); ok {
+		return dir.(string)
+	}
+
+	dir := dirLTR
+	name := unicodeScriptNames()[normalizeScriptName(display.English.Scripts().Name(script))]
+	if table, ok := unicode.Scripts[name]; ok {
+		var rtl, ltr int
+		countBidiClasses(table, &rtl, &ltr)
+		if rtl > ltr {
+			dir = dirRTL
+		}
+	}
+
+	scriptDirections.Store(script, dir)
+	return dir
+}
+
+// countBidiClasses counts the runes of table with a strong right-to-left and
+// left-to-right bidi class.
+func countBidiClasses(table *unicode.RangeTable, rtl, ltr *int) {
+	count := func(r rune) {
+		props, _ := bidi.LookupRune(r)
+		switch props.Class() {
+		case bidi.R, bidi.AL:
+			*rtl++
+		case bidi.L:
+			*ltr++
+		}
+	}
+	for _, rng := range table.R16 {
+		for r := rune(rng.Lo); r <= rune(rng.Hi); r += rune(rng.Stride) {
+			count(r)
+		}
+	}
+	for _, rng := range table.R32 {
+		for r := rune(rng.Lo); r <= rune(rng.Hi); r += rune(rng.Stride) {
+			count(r)
+		}
+	}
+}
+
+// normalizeScriptName reduces a script name to its lowercase letters, so that
+// the CLDR name "N’Ko" matches the Unicode name "Nko" and "Old Italic"
+// matches "Old_Italic".
+func normalizeScriptName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// bidiIsolate wraps s in FSI and PDI, so that its direction is determined by
+// its own first strong character and it doesn't affect the surrounding text,
+// e.g. a Latin order number inside an Arabic sentence.
+func bidiIsolate(s string) string {
+	if s == "" {
+		return s
+	}
+	return fsi + s + pdi
+}
+
+// validateBidiIsolation checks a bidi isolation mode.
+func validateBidiIsolation(mode string) error {
+	switch mode {
+	case "", bidiIsolationAuto, bidiIsolationAlways, bidiIsolationNever:
+		return nil
+	}
+	return fmt.Errorf("invalid bidi_isolation %q: must be %q, %q or %q", mode, bidiIsolationAuto, bidiIsolationAlways, bidiIsolationNever)
+}
+
+// isolateArgs reports whether interpolated arguments of a translation in lang
+// are wrapped in bidi isolates.
+func (i *I18n) isolateArgs(lang string) bool {
+	switch i.BidiIsolation {
+	case bidiIsolationAlways:
+		return true
+	case bidiIsolationAuto:
+		return languageDirection(lang) == dirRTL
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLanguageDirection(t *testing.T) {
+	tests := map[string]string{
+		"en":      "ltr",
+		"de-CH":   "ltr",
+		"ar":      "rtl",
+		"ar-XB":   "rtl",
+		"he":      "rtl",
+		"fa":      "rtl",
+		"ur":      "rtl",
+		"yi":      "rtl",
+		"dv":      "rtl",
+		"syr":     "rtl",
+		"nqo":     "rtl",
+		"ckb":     "rtl",
+		"az":      "ltr",
+		"az-Arab": "rtl",
+		"pa":      "ltr",
+		"pa-Arab": "rtl",
+		"zh-Hant": "ltr",
+		"ja":      "ltr",
+		"ru":      "ltr",
+		"invalid": "ltr",
+		"":        "ltr",
+	}
+	for lang, expected := range tests {
+		if result := languageDirection(lang); result != expected {
+			t.Errorf("languageDirection(%q): expected %q, got %q", lang, expected, result)
+		}
+	}
+}
+
+func TestI18nDirAndIsolate(t *testing.T) {
+	i18n := &I18n{}
+	funcMap := i18n.CustomTemplateFunctions()
+
+	dirFunc := funcMap["i18nDir"].(func(string) (string, error))
+	if dir, _ := dirFunc("he"); dir != "rtl" {
+		t.Errorf("expected 'rtl' for he, got %q", dir)
+	}
+	if dir, _ := dirFunc("de"); dir != "ltr" {
+		t.Errorf("expected 'ltr' for de, got %q", dir)
+	}
+
+	isolateFunc := funcMap["i18nIsolate"].(func(interface{}) (string, error))
+	if result, _ := isolateFunc(4711); result != "\u20684711\u2069" {
+		t.Errorf("expected isolated number, got %q", result)
+	}
+	if result, _ := isolateFunc(""); result != "" {
+		t.Errorf("expected empty string to stay empty, got %q", result)
+	}
+}
+
+func TestInterpolationBidiIsolation(t *testing.T) {
+	translations := map[string]map[string]string{
+		"order":  {"ar": "الطلب {0} من {1}", "en": "Order {0} from {1}"},
+		"status": {"ar": "مشحون", "en": "shipped"},
+		"german": {"en": "Order {0}"},
+	}
+
+	tests := []struct {
+		mode     string
+		key      string
+		lang     string
+		expected string
+	}{
+		// The default keeps the output of earlier versions.
+		{"", "order", "ar", "الطلب A-1001 من مشحون"},
+		{"auto", "order", "ar", "الطلب \u2068A-1001\u2069 من \u2068مشحون\u2069"},
+		{"auto", "order", "en", "Order A-1001 from shipped"},
+		{"auto", "german", "ar", "Order A-1001"},
+		{"always", "order", "en", "Order \u2068A-1001\u2069 from \u2068shipped\u2069"},
+		{"never", "order", "ar", "الطلب A-1001 من مشحون"},
+	}
+
+	for _, tt := range tests {
+		i18n := &I18n{translations: translations, BidiIsolation: tt.mode}
+		i18n.mu = new(sync.RWMutex)
+		i18n.logger = zaptest.NewLogger(t)
+
+		result := i18n.translate(nil, tt.key, tt.lang, []interface{}{"A-1001", "i18n:status"})
+		if result != tt.expected {
+			t.Errorf("mode %q, %s/%s: expected %q, got %q", tt.mode, tt.key, tt.lang, tt.expected, result)
+		}
+	}
+}
+
+func TestValidateBidiIsolation(t *testing.T) {
+	if err := validateBidiIsolation("rtl"); err == nil {
+		t.Error("expected error for invalid mode, got nil")
+	}
+}
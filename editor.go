@@ -0,0 +1,318 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"go.uber.org/zap"
+)
+
+func init() {
+	caddy.RegisterModule(Editor{})
+}
+
+// editorOverlayJS is the client-side overlay served at <path>/overlay.js.
+//
+//go:embed editor_overlay.js
+var editorOverlayJS []byte
+
+// maxEditorRequestBody limits the size of an edit request.
+const maxEditorRequestBody = 64 << 10
+
+// dictWriteMu serializes writes to dictionary files.
+var dictWriteMu sync.Mutex
+
+// errNotPlainString is returned for translations with plural, ordinal or
+// select forms, which the editor can neither show nor change.
+var errNotPlainString = errors.New("is not a plain string")
+
+// Editor is an HTTP handler that supports in-context translation editing.
+// It serves a small JavaScript overlay and an authenticated endpoint that
+// writes edited translations back to the dictionary file. After each edit,
+// all i18n template extensions using that file reload it.
+//
+// Pages must be rendered with the debug_keys "edit" mode, so that translations
+// are wrapped in <span data-i18n-key="..." data-i18n-lang="..."> elements, and
+// include the overlay script.
+//
+// Endpoints, relative to the path the handler is mounted on:
+//   - GET .../overlay.js: The overlay script
+//   - GET .../translations?key=...&lang=...: The current translation as JSON
+//   - POST .../translations: Sets a translation from {"key", "lang", "value"}
+//
+// Other requests are passed to the next handler.
+type Editor struct {
+	// DictFile is the path to the dictionary file edits are written to.
+	DictFile string `json:"dict_file,omitempty"`
+
+	// Token is the secret the overlay must send as "Authorization: Bearer <token>".
+	// Placeholders such as {env.I18N_EDITOR_TOKEN} are supported.
+	Token string `json:"token,omitempty"`
+
+	token  string
+	logger *zap.Logger
+}
+
+// CaddyModule returns the Caddy module information for registration.
+func (Editor) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "http.handlers.i18n_editor",
+		New: func() caddy.Module { return new(Editor) },
+	}
+}
+
+// Provision validates the configuration and resolves the token.
+func (e *Editor) Provision(ctx caddy.Context) error {
+	e.logger = ctx.Logger()
+
+	if e.DictFile == "" {
+		return errors.New("i18n_editor: dict_file is required")
+	}
+
+	e.token = caddy.NewReplacer().ReplaceAll(e.Token, "")
+	if e.token == "" {
+		return errors.New("i18n_editor: token is required")
+	}
+
+	return nil
+}
+
+// ServeHTTP implements caddyhttp.MiddlewareHandler.
+func (e *Editor) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/overlay.js"):
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			return caddyhttp.Error(http.StatusMethodNotAllowed, nil)
+		}
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, err := w.Write(editorOverlayJS)
+		return err
+
+	case strings.HasSuffix(r.URL.Path, "/translations"):
+		if !e.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="i18n editor"`)
+			return caddyhttp.Error(http.StatusUnauthorized, errors.New("invalid or missing editor token"))
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return e.serveTranslation(w, r)
+		case http.MethodPost:
+			return e.updateTranslation(w, r)
+		}
+		return caddyhttp.Error(http.StatusMethodNotAllowed, nil)
+	}
+
+	return next.ServeHTTP(w, r)
+}
+
+// authorized reports whether r carries the configured token.
+func (e *Editor) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(e.token)) == 1
+}
+
+// editorTranslation is the JSON representation of a single translation.
+type editorTranslation struct {
+	Key    string `json:"key"`
+	Lang   string `json:"lang"`
+	Value  string `json:"value"`
+	Exists bool   `json:"exists"`
+}
+
+// serveTranslation responds with the current translation from the dictionary file.
+func (e *Editor) serveTranslation(w http.ResponseWriter, r *http.Request) error {
+	t := editorTranslation{
+		Key:  r.URL.Query().Get("key"),
+		Lang: r.URL.Query().Get("lang"),
+	}
+	if t.Key == "" || t.Lang == "" {
+		return caddyhttp.Error(http.StatusBadRequest, errors.New("key and lang are required"))
+	}
+
+	dict, err := readRawDictionary(e.DictFile)
+	if err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	if raw, ok := dict[t.Key][t.Lang]; ok {
+		if err := json.Unmarshal(raw, &t.Value); err != nil {
+			return caddyhttp.Error(http.StatusUnprocessableEntity, fmt.Errorf("translation of %s in %s %w", t.Key, t.Lang, errNotPlainString))
+		}
+		t.Exists = true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(t)
+}
+
+// updateTranslation writes an edited translation to the dictionary file and
+// reloads all i18n instances using it.
+func (e *Editor) updateTranslation(w http.ResponseWriter, r *http.Request) error {
+	var t editorTranslation
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxEditorRequestBody))
+	if err := dec.Decode(&t); err != nil {
+		return caddyhttp.Error(http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+	}
+	if t.Key == "" || t.Lang == "" {
+		return caddyhttp.Error(http.StatusBadRequest, errors.New("key and lang are required"))
+	}
+	for _, i := range instances.forDictFile(e.DictFile) {
+		if i.Pseudo.locale(t.Lang) {
+			// Lookups generate pseudo-locales from the source language
+			// and never read an entry for them.
+			return caddyhttp.Error(http.StatusUnprocessableEntity, fmt.Errorf("%s is a pseudo-locale and cannot be edited", t.Lang))
+		}
+	}
+
+	if err := writeTranslation(e.DictFile, t.Key, t.Lang, t.Value); err != nil {
+		if errors.Is(err, errNotPlainString) {
+			return caddyhttp.Error(http.StatusUnprocessableEntity, err)
+		}
+		return caddyhttp.Error(http.StatusInternalServerError, err)
+	}
+	e.logger.Info("translation edited",
+		zap.String("dict_file", e.DictFile),
+		zap.String("key", t.Key),
+		zap.String("lang", t.Lang),
+		zap.String("remote_ip", r.RemoteAddr),
+	)
+
+	reload := append(instances.forDictFile(e.DictFile), internalDictionaries.forDictFile(e.DictFile)...)
+	if err := reloadDictionaries(reload); err != nil {
+		return caddyhttp.Error(http.StatusInternalServerError, fmt.Errorf("reloading dictionary: %w", err))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// reloadDictionaries reloads the dictionary of every instance in list. An
+// instance that fails to reload doesn't keep the others from being reloaded;
+// the errors of all of them are returned together.
+func reloadDictionaries(list []*I18n) error {
+	var errs []error
+	for _, i := range list {
+		if err := i.reloadDictionary(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readRawDictionary reads a dictionary file without interpreting the translations.
+func readRawDictionary(path string) (map[string]map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var dict map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &dict); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON dictionary: %w", err)
+	}
+	if dict == nil {
+		dict = make(map[string]map[string]json.RawMessage)
+	}
+	return dict, nil
+}
+
+// writeTranslation sets the translation of key in lang and writes the
+// dictionary file back atomically. Other entries are preserved as they are,
+// but keys are written in alphabetical order.
+// Translations with forms are not replaced; errNotPlainString is returned
+// instead.
+func writeTranslation(path, key, lang, value string) error {
+	dictWriteMu.Lock()
+	defer dictWriteMu.Unlock()
+
+	dict, err := readRawDictionary(path)
+	if err != nil {
+		return err
+	}
+
+	var current string
+	if existing, ok := dict[key][lang]; ok && json.Unmarshal(existing, &current) != nil {
+		return fmt.Errorf("translation of %s in %s %w", key, lang, errNotPlainString)
+	}
+
+	raw, err := marshalJSON(value, "")
+	if err != nil {
+		return err
+	}
+	if dict[key] == nil {
+		dict[key] = make(map[string]json.RawMessage)
+	}
+	dict[key][lang] = bytes.TrimSpace(raw)
+
+	data, err := marshalJSON(dict, "  ")
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// marshalJSON encodes v without escaping HTML characters, so that
+// translations containing markup stay readable in the dictionary file.
+func marshalJSON(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Interface guards ensure that Editor implements the required interfaces.
+var (
+	_ caddy.Provisioner           = (*Editor)(nil)
+	_ caddyhttp.MiddlewareHandler = (*Editor)(nil)
+)
@@ -0,0 +1,90 @@
+// In-context translation editor overlay for caddy-i18n-template-ext.
+//
+// Include it on pages rendered with the debug_keys "edit" mode:
+//
+//   <script src="/_i18n/overlay.js" defer></script>
+//
+// Alt+click (Option+click on macOS) a highlighted string to edit it.
+// The editor token is asked for once and kept in sessionStorage.
+(function () {
+  "use strict";
+
+  var script = document.currentScript;
+  var endpoint = (script && script.getAttribute("data-endpoint")) ||
+    (script ? script.src.replace(/overlay\.js(\?.*)?$/, "translations") : "/_i18n/translations");
+  var tokenKey = "i18n-editor-token";
+
+  var style = document.createElement("style");
+  style.textContent =
+    "[data-i18n-key]{outline:1px dashed rgba(0,120,215,.6);cursor:text}" +
+    "[data-i18n-key]:hover{outline:2px solid #0078d7;background:rgba(0,120,215,.08)}" +
+    "[data-i18n-key].i18n-busy{opacity:.5}";
+  document.head.appendChild(style);
+
+  function token() {
+    var t = sessionStorage.getItem(tokenKey);
+    if (!t) {
+      t = window.prompt("i18n editor token");
+      if (t) {
+        sessionStorage.setItem(tokenKey, t);
+      }
+    }
+    return t;
+  }
+
+  function request(method, query, body) {
+    var t = token();
+    if (!t) {
+      return Promise.reject(new Error("no token"));
+    }
+    return fetch(endpoint + query, {
+      method: method,
+      headers: { "Content-Type": "application/json", "Authorization": "Bearer " + t },
+      body: body ? JSON.stringify(body) : undefined
+    }).then(function (res) {
+      if (res.status === 401) {
+        sessionStorage.removeItem(tokenKey);
+      }
+      if (!res.ok) {
+        return res.text().then(function (msg) {
+          throw new Error(res.status + " " + msg);
+        });
+      }
+      return res.status === 204 ? null : res.json();
+    });
+  }
+
+  function edit(el) {
+    var key = el.getAttribute("data-i18n-key");
+    var lang = el.getAttribute("data-i18n-lang");
+    var query = "?key=" + encodeURIComponent(key) + "&lang=" + encodeURIComponent(lang);
+
+    el.classList.add("i18n-busy");
+    request("GET", query).then(function (current) {
+      var value = window.prompt(key + " [" + lang + "]\n\nPlaceholders like {0} are replaced with arguments.", current.value);
+      if (value === null || value === current.value) {
+        return null;
+      }
+      return request("POST", "", { key: key, lang: lang, value: value }).then(function () {
+        window.location.reload();
+      });
+    }).catch(function (err) {
+      window.alert("Editing " + key + " failed: " + err.message);
+    }).then(function () {
+      el.classList.remove("i18n-busy");
+    });
+  }
+
+  document.addEventListener("click", function (ev) {
+    if (!ev.altKey) {
+      return;
+    }
+    var el = ev.target.closest("[data-i18n-key]");
+    if (!el) {
+      return;
+    }
+    ev.preventDefault();
+    ev.stopPropagation();
+    edit(el);
+  }, true);
+})();
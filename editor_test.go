@@ -0,0 +1,244 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"go.uber.org/zap/zaptest"
+)
+
+var errNextCalled = errors.New("next handler called")
+
+var nextHandler = caddyhttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+	return errNextCalled
+})
+
+func newTestEditor(t *testing.T, dictFile string) *Editor {
+	t.Helper()
+	e := &Editor{DictFile: dictFile, Token: "secret"}
+	var stubCaddyCtx caddy.Context
+	if err := e.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	e.logger = zaptest.NewLogger(t)
+	return e
+}
+
+func TestEditorProvisionRequiresToken(t *testing.T) {
+	e := &Editor{DictFile: "dict.json"}
+	var stubCaddyCtx caddy.Context
+	if err := e.Provision(stubCaddyCtx); err == nil {
+		t.Fatal("expected error for missing token")
+	}
+
+	e = &Editor{Token: "secret"}
+	if err := e.Provision(stubCaddyCtx); err == nil {
+		t.Fatal("expected error for missing dict_file")
+	}
+}
+
+func TestEditorServesOverlay(t *testing.T) {
+	e := newTestEditor(t, "dict.json")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/_i18n/overlay.js", nil)
+	if err := e.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/javascript") {
+		t.Errorf("unexpected Content-Type %q", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "data-i18n-key") {
+		t.Error("expected overlay script in response body")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/other", nil)
+	if err := e.ServeHTTP(httptest.NewRecorder(), req, nextHandler); err != errNextCalled {
+		t.Errorf("expected other paths to be passed on, got %v", err)
+	}
+}
+
+func TestEditorRequiresToken(t *testing.T) {
+	e := newTestEditor(t, "dict.json")
+
+	for _, auth := range []string{"", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{}`))
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		err := e.ServeHTTP(httptest.NewRecorder(), req, nextHandler)
+		var handlerErr caddyhttp.HandlerError
+		if !errors.As(err, &handlerErr) || handlerErr.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %v", auth, err)
+		}
+	}
+}
+
+func TestEditorUpdatesDictionaryAndReloads(t *testing.T) {
+	dictFile := createTestDictFile(t, `{
+		"hello": {"de": "Hallo", "en": "Hello"},
+		"bold": {"en": "<b>Bold</b>"}
+	}`)
+
+	i18n := &I18n{DictFile: dictFile}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	e := newTestEditor(t, dictFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/_i18n/translations?key=hello&lang=de", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	if err := e.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	var current editorTranslation
+	if err := json.NewDecoder(rec.Body).Decode(&current); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !current.Exists || current.Value != "Hallo" {
+		t.Errorf("unexpected current translation: %+v", current)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{"key": "hello", "lang": "fr", "value": "Bonjour"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	if err := e.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rec.Code)
+	}
+
+	if result := i18n.translate(nil, "hello", "fr", nil); result != "Bonjour" {
+		t.Errorf("expected reloaded translation 'Bonjour', got %q", result)
+	}
+
+	data, err := os.ReadFile(dictFile)
+	if err != nil {
+		t.Fatalf("failed to read dictionary: %v", err)
+	}
+	if !strings.Contains(string(data), `"fr": "Bonjour"`) {
+		t.Errorf("expected new translation in file, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"en": "<b>Bold</b>"`) {
+		t.Errorf("expected other entries to be preserved unescaped, got:\n%s", data)
+	}
+}
+
+func TestEditorRejectsInvalidRequests(t *testing.T) {
+	dictFile := createTestDictFile(t, `{}`)
+	e := newTestEditor(t, dictFile)
+
+	for _, body := range []string{`not json`, `{"key": "", "lang": "de", "value": "x"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		err := e.ServeHTTP(httptest.NewRecorder(), req, nextHandler)
+		var handlerErr caddyhttp.HandlerError
+		if !errors.As(err, &handlerErr) || handlerErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("body %q: expected 400, got %v", body, err)
+		}
+	}
+}
+
+func TestEditorRejectsTranslationsWithForms(t *testing.T) {
+	dict := `{"items": {"en": {"one": "{0} item", "other": "{0} items"}}}`
+	dictFile := createTestDictFile(t, dict)
+	e := newTestEditor(t, dictFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{"key": "items", "lang": "en", "value": "items"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	err := e.ServeHTTP(httptest.NewRecorder(), req, nextHandler)
+	var handlerErr caddyhttp.HandlerError
+	if !errors.As(err, &handlerErr) || handlerErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %v", err)
+	}
+
+	data, err := os.ReadFile(dictFile)
+	if err != nil {
+		t.Fatalf("failed to read dictionary: %v", err)
+	}
+	if string(data) != dict {
+		t.Errorf("expected dictionary to be unchanged, got:\n%s", data)
+	}
+}
+
+func TestEditorRejectsPseudoLocales(t *testing.T) {
+	dict := `{"hello": {"en": "Hello"}}`
+	dictFile := createTestDictFile(t, dict)
+
+	i18n := &I18n{DictFile: dictFile, Pseudo: &PseudoConfig{}}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	e := newTestEditor(t, dictFile)
+	for _, lang := range []string{pseudoAccented, pseudoBidi} {
+		req := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{"key": "hello", "lang": "`+lang+`", "value": "x"}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		err := e.ServeHTTP(httptest.NewRecorder(), req, nextHandler)
+		var handlerErr caddyhttp.HandlerError
+		if !errors.As(err, &handlerErr) || handlerErr.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("%s: expected 422, got %v", lang, err)
+		}
+	}
+
+	data, err := os.ReadFile(dictFile)
+	if err != nil {
+		t.Fatalf("failed to read dictionary: %v", err)
+	}
+	if string(data) != dict {
+		t.Errorf("expected dictionary to be unchanged, got:\n%s", data)
+	}
+}
+
+func TestReloadDictionariesContinuesAfterErrors(t *testing.T) {
+	dictFile := createTestDictFile(t, `{"hello": {"en": "Hello"}}`)
+
+	broken := &I18n{DictFile: filepath.Join(t.TempDir(), "missing.json"), mu: new(sync.RWMutex), internal: true}
+	working := &I18n{DictFile: dictFile, mu: new(sync.RWMutex), internal: true}
+	otherBroken := &I18n{DictFile: filepath.Join(t.TempDir(), "gone.json"), mu: new(sync.RWMutex), internal: true}
+
+	err := reloadDictionaries([]*I18n{broken, working, otherBroken})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, name := range []string{"missing.json", "gone.json"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected error to mention %s, got %v", name, err)
+		}
+	}
+	if result := working.translate(nil, "hello", "en", nil); result != "Hello" {
+		t.Errorf("expected the working instance to be reloaded, got %q", result)
+	}
+}
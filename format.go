@@ -0,0 +1,85 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// argFormatter formats an interpolated argument for a placeholder with a
+// format, such as {0, number, max=2}. style is the text after the second
+// comma, or "" if there is none.
+type argFormatter func(i *I18n, r *http.Request, lang string, arg interface{}, style string) (string, error)
+
+// argFormatters maps the format names usable in placeholders to their
+// formatters.
+var argFormatters = map[string]argFormatter{
+	"number": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatNumber(arg, lang, style)
+	},
+	"percent": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatPercent(arg, lang, style)
+	},
+	"compact": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatCompact(arg, lang, style)
+	},
+	"currency": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatCurrency(arg, "", lang, style)
+	},
+	"date": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatDate(arg, lang, style)
+	},
+	"time": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatTime(arg, lang, style)
+	},
+	"relative": func(i *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return formatRelativeTime(arg, i.currentTime(), lang, style)
+	},
+	"list": func(i *I18n, r *http.Request, lang string, arg interface{}, style string) (string, error) {
+		return i.formatList(r, arg, lang, style)
+	},
+	"unit": func(_ *I18n, _ *http.Request, lang string, arg interface{}, style string) (string, error) {
+		unit, opts, _ := strings.Cut(strings.TrimSpace(style), " ")
+		if unit == "" {
+			return "", fmt.Errorf("no unit given")
+		}
+		return formatUnit(arg, unit, lang, opts)
+	},
+}
+
+// formatPlaceholderArg formats arg for a placeholder of the translation key
+// with the given format and style. If the format is unknown or arg cannot be
+// formatted, a warning is logged like other lookup problems and the argument
+// is used as is.
+func (i *I18n) formatPlaceholderArg(r *http.Request, key, lang string, arg interface{}, format, style string) string {
+	formatter, ok := argFormatters[format]
+	if !ok {
+		i.logLookup(zapcore.WarnLevel, "unknown placeholder format, using argument as is", key, lang,
+			zap.String("format", format))
+		return i.formatArg(r, lang, arg)
+	}
+	result, err := formatter(i, r, lang, arg, style)
+	if err != nil {
+		i.logLookup(zapcore.WarnLevel, "cannot format placeholder argument, using argument as is", key, lang,
+			zap.String("format", format), zap.String("style", style), zap.Error(err))
+		return i.formatArg(r, lang, arg)
+	}
+	return result
+}
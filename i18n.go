@@ -15,26 +15,35 @@
 package i18n
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/caddyserver/caddy/v2"
 	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func init() {
 	caddy.RegisterModule(I18n{})
 }
 
+// placeholderRegexp finds placeholders like {0}, {1}, etc., optionally with a
+// format and style, e.g. {0, number} or {0, number, max=2}.
+var placeholderRegexp = regexp.MustCompile(`\{(\d+)(?:\s*,\s*([a-z]+)(?:\s*,([^{}]*))?)?\s*\}`)
+
 // I18n implements a simple internationalization (i18n) template extension for Caddy v2.
 // It loads translation dictionaries from a JSON file and provides template functions
 // for dictionary-based translation lookups with support for nested translations and
@@ -59,14 +68,108 @@ func init() {
 //	{{ i18nTranslate "error.invalidAmount" "en" "i18n:finance.account" }}
 type I18n struct {
 	// DictFile is the path to the translations dictionary file in JSON format.
-	// Structure: map[translationKey]map[languageCode]translatedText
+	// Structure: map[translationKey]map[languageCode]translatedText, where a
+	// translated text may also be an object of forms, see i18nOrdinal and
+	// i18nSelect.
 	// Example: "/etc/caddy/translations.json"
 	DictFile string `json:"dict_file,omitempty"`
 
+	// RequiredLanguages lists the languages every translation key is expected
+	// to have. The coverage report logged during provisioning and served by the
+	// admin API at /i18n/coverage lists the gaps per language.
+	// If empty, all languages found in the dictionary are checked.
+	// Example: ["de", "en", "fr"]
+	RequiredLanguages []string `json:"required_languages,omitempty"`
+
+	// MaxMissingKeys limits how many distinct missing keys and key/language
+	// pairs are collected at runtime and served by the admin API at
+	// /i18n/missing. Defaults to 1000. A negative value disables the collector.
+	MaxMissingKeys int `json:"max_missing_keys,omitempty"`
+
+	// MissingLogInterval deduplicates the logs written for missing translations
+	// and language fallbacks: each message is written at most once per interval
+	// and key/language pair, together with the number of suppressed occurrences.
+	// If zero, every occurrence is logged.
+	// Example: "5m"
+	MissingLogInterval caddy.Duration `json:"missing_log_interval,omitempty"`
+
+	// MissingLogLevel is the level for logs about missing translations.
+	// One of "debug", "info", "warn" or "error". Defaults to "warn".
+	MissingLogLevel string `json:"missing_log_level,omitempty"`
+
+	// LogFallbacks controls whether falling back to 'en' is logged at info
+	// level. Defaults to true.
+	LogFallbacks *bool `json:"log_fallbacks,omitempty"`
+
+	// Pseudo enables the pseudo-locales "en-XA" (accented, expanded and
+	// bracketed) and "ar-XB" (right-to-left mirrored) for layout testing.
+	// Pseudo-localization is disabled if not set.
+	Pseudo *PseudoConfig `json:"pseudo,omitempty"`
+
+	// DebugKeys allows QA to show translation keys on a page by setting a
+	// query parameter, cookie or header. It only applies to request-aware
+	// lookups such as i18nTranslateCtx. The debug mode cannot be turned on
+	// if not set.
+	DebugKeys *DebugKeysConfig `json:"debug_keys,omitempty"`
+
+	// LangPlaceholders are the Caddy placeholders i18nT reads the language
+	// from, in order. The first one resolving to a non-empty value is used;
+	// if none does, "en" is used. Defaults to ["{http.vars.lang}", "{http.i18n.lang}"],
+	// the latter being set by the i18n_negotiate handler.
+	// Example: ["{http.vars.lang}", "{http.request.cookie.lang}", "{http.request.header.X-Lang}"]
+	LangPlaceholders []string `json:"lang_placeholders,omitempty"`
+
+	// ContentLanguage controls whether request-aware lookups such as i18nT
+	// set the Content-Language response header to the languages used while
+	// rendering. Defaults to true.
+	ContentLanguage *bool `json:"content_language,omitempty"`
+
+	// LocaleURLs describes how the URL of a page differs between languages,
+	// as used by i18nAlternates. Defaults to a language path prefix such as
+	// /de/about.
+	LocaleURLs *LocaleURLConfig `json:"locale_urls,omitempty"`
+
+	// BidiIsolation controls whether interpolated arguments are wrapped in
+	// the Unicode bidi isolates FSI and PDI, so that e.g. a Latin order
+	// number doesn't scramble an Arabic sentence. "auto" isolates arguments
+	// in translations written right-to-left, "always" in all translations
+	// and "never" not at all. Defaults to "never". In "auto" mode, the
+	// direction of pseudo-locales is that of their source language.
+	BidiIsolation string `json:"bidi_isolation,omitempty"`
+
 	// translations holds the in-memory translation dictionary.
 	// Structure: map[translationKey]map[languageCode]translatedText
 	translations map[string]map[string]string
 
+	// variants holds the forms of translations given as an object, such as
+	// the ordinal forms {"one": "{0}st", ..., "other": "{0}th"} or the select
+	// forms {"male": "...", "female": "...", "other": "..."}. Their
+	// "other" form is also in translations.
+	// Structure: map[translationKey]map[languageCode]forms
+	variants map[string]map[string]pluralForms
+
+	// missing collects translation misses at runtime. It is nil if disabled.
+	missing *missingKeys
+
+	// logDedup limits repeated lookup logs. It is nil if deduplication is disabled.
+	logDedup *logDeduper
+
+	// langCounts holds the number of translated keys per language of the
+	// loaded dictionary. It is used to bound the lang label of metrics.
+	langCounts map[string]int
+
+	// dictSum is the SHA-256 checksum of the loaded dictionary file. It is
+	// used to count only loads that changed the dictionary in the metrics.
+	dictSum [sha256.Size]byte
+
+	// internal is set for dictionaries loaded by newDictionary, whose
+	// reloads are not recorded in the metrics.
+	internal bool
+
+	// now returns the reference time of relative times. It is nil for
+	// time.Now and set by tests.
+	now func() time.Time
+
 	// mu protects concurrent access to the translations map.
 	mu *sync.RWMutex
 
@@ -94,19 +197,73 @@ func (i *I18n) Provision(ctx caddy.Context) error {
 	// Initialize the translations map
 	i.translations = make(map[string]map[string]string)
 
+	// Initialize the missing-key collector unless disabled
+	switch {
+	case i.MaxMissingKeys == 0:
+		i.missing = newMissingKeys(defaultMaxMissingKeys)
+	case i.MaxMissingKeys > 0:
+		i.missing = newMissingKeys(i.MaxMissingKeys)
+	}
+
+	// Validate the log level and set up log deduplication
+	if _, err := parseMissingLogLevel(i.MissingLogLevel); err != nil {
+		return err
+	}
+	if i.MissingLogInterval > 0 {
+		i.logDedup = newLogDeduper(time.Duration(i.MissingLogInterval))
+	}
+
+	if i.DebugKeys != nil {
+		if err := i.DebugKeys.validate(); err != nil {
+			return err
+		}
+	}
+
+	if i.LocaleURLs != nil {
+		if err := i.LocaleURLs.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateBidiIsolation(i.BidiIsolation); err != nil {
+		return err
+	}
+
+	// Register lookup metrics with Caddy's metrics registry
+	if err := registerMetrics(ctx.GetMetricsRegistry()); err != nil {
+		return fmt.Errorf("failed to register i18n metrics: %w", err)
+	}
+	i.langCounts = make(map[string]int)
+
 	// Load translations from the dictionary file if configured
 	if i.DictFile != "" {
-		if err := i.loadDictionary(); err != nil {
+		err := i.loadDictionary()
+		i.recordDictionaryLoad(err)
+		if err != nil {
 			return fmt.Errorf("failed to load i18n dictionary: %w", err)
 		}
 		i.logger.Info("i18n dictionary loaded successfully", zap.String("dict_file", i.DictFile))
+		i.logCoverage()
 	}
 
+	instances.add(i)
+
 	return nil
 }
 
-// CustomTemplateFunctions returns a FuncMap with the i18nTranslate template function.
-// This function is used within Caddy templates to translate messages based on language codes.
+// Cleanup unregisters the instance from the admin API and removes the
+// dictionary metrics once no other instance uses the same file.
+func (i *I18n) Cleanup() error {
+	instances.remove(i)
+	if i.DictFile != "" && len(instances.forDictFile(i.DictFile)) == 0 {
+		forgetDictionaryMetrics(i.DictFile)
+	}
+	return nil
+}
+
+// CustomTemplateFunctions returns a FuncMap with the i18n template functions.
+// These functions are used within Caddy templates to translate messages and to
+// format values based on language codes. README.md documents each of them.
 //
 // Function signature: i18nTranslate(key string, lang string, args ...interface{}) string
 //
@@ -122,142 +279,349 @@ func (i *I18n) Provision(ctx caddy.Context) error {
 //   - If "en" also doesn't exist: Returns key as fallback, logs warning
 //   - Replaces {0}, {1}, etc. in translation with provided arguments
 //
+// The functions taking the template context as their first argument, such as
+// i18nTranslateCtx and i18nT, also use the request, e.g. for debug keys.
+//
 // Example:
 //
 //	{{ i18nTranslate "error.invalidAmount" "de" "500.99" }}
 //	{{ i18nTranslate "error.account" "en" "i18n:finance.account" }}
+//	{{ i18nT . "welcome" }}
 func (i *I18n) CustomTemplateFunctions() template.FuncMap {
 	return template.FuncMap{
 		"i18nTranslate": func(key, lang string, args ...interface{}) (string, error) {
+			return i.translate(nil, key, lang, args), nil
+		},
+		// i18nTranslate with the template context, for missing-key paths, debug keys and Content-Language
+		"i18nTranslateCtx": func(ctx *templates.TemplateContext, key, lang string, args ...interface{}) (string, error) {
+			return i.translateCtx(ctx, key, lang, args, nil), nil
+		},
+		// i18nTranslateCtx with the language read from LangPlaceholders
+		"i18nT": func(ctx *templates.TemplateContext, key string, args ...interface{}) (string, error) {
+			return i.translateCtx(ctx, key, i.templateLang(ctx), args, nil), nil
+		},
+		// Picks the form for the CLDR ordinal plural category of n
+		"i18nOrdinal": func(ctx *templates.TemplateContext, key, lang string, n interface{}, args ...interface{}) (string, error) {
+			return i.translateOrdinal(ctx, key, lang, n, args)
+		},
+		// Picks the form named by value, e.g. a grammatical gender
+		"i18nSelect": func(ctx *templates.TemplateContext, key, lang string, value interface{}, args ...interface{}) (string, error) {
+			return i.translateSelect(ctx, key, lang, value, args), nil
+		},
+		// hreflang alternate links for the current page, built according to LocaleURLs
+		"i18nAlternates": func(ctx *templates.TemplateContext) (string, error) {
+			return i.alternateLinks(requestOf(ctx)), nil
+		},
+		// Languages of the dictionary for a language switcher
+		"i18nLanguages": func(ctx *templates.TemplateContext) ([]LanguageOption, error) {
+			return i.languageOptions(requestOf(ctx), i.templateLang(ctx)), nil
+		},
+		// Numbers, percentages, compact numbers and currencies with the CLDR conventions of lang
+		"i18nNumber": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatNumber(value, lang, strings.Join(opts, " "))
+		},
+		"i18nPercent": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatPercent(value, lang, strings.Join(opts, " "))
+		},
+		"i18nCompact": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatCompact(value, lang, strings.Join(opts, " "))
+		},
+		"i18nCurrency": func(amount interface{}, code, lang string, opts ...string) (string, error) {
+			return formatCurrency(amount, code, lang, strings.Join(opts, " "))
+		},
+		// Dates, times and relative times with the CLDR patterns of lang
+		"i18nDate": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatDate(value, lang, strings.Join(opts, " "))
+		},
+		"i18nTime": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatTime(value, lang, strings.Join(opts, " "))
+		},
+		"i18nRelativeTime": func(value interface{}, lang string, opts ...string) (string, error) {
+			return formatRelativeTime(value, i.currentTime(), lang, strings.Join(opts, " "))
+		},
+		// Lists and measurements with the CLDR patterns of lang
+		"i18nList": func(items interface{}, lang string, opts ...string) (string, error) {
 			i.mu.RLock()
 			defer i.mu.RUnlock()
+			return i.formatList(nil, items, lang, strings.Join(opts, " "))
+		},
+		"i18nUnit": func(value interface{}, unit, lang string, opts ...string) (string, error) {
+			return formatUnit(value, unit, lang, strings.Join(opts, " "))
+		},
+		// Text direction and bidi isolation
+		"i18nDir": func(lang string) (string, error) {
+			return languageDirection(lang), nil
+		},
+		"i18nIsolate": func(value interface{}) (string, error) {
+			return bidiIsolate(fmt.Sprint(value)), nil
+		},
+		// JSON object of translations for client-side use
+		"i18nBundle": func(lang string, prefixes ...string) (string, error) {
+			return i.bundleJSON(lang, prefixes)
+		},
+	}
+}
 
-			// Check if the translation key exists
-			entry, ok := i.translations[key]
-			if !ok {
-				// Log a warning and return the key itself as a sensible fallback
-				if i.logger != nil {
-					i.logger.Warn("translation key not found, using key as fallback", zap.String("key", key))
-				}
-				return key, nil
-			}
+// requestOf returns the HTTP request of a template context, or nil.
+func requestOf(ctx *templates.TemplateContext) *http.Request {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Req
+}
 
-			// If requested language exists, use it
-			val, ok := entry[lang]
-			if !ok {
-				// Try English as fallback language
-				val, ok = entry["en"]
-				if !ok {
-					// Final fallback: log warning and return key
-					if i.logger != nil {
-						i.logger.Warn(
-							"no translation for requested language or 'en', using key as fallback",
-							zap.String("key", key),
-							zap.String("requested_lang", lang),
-						)
-					}
-					return key, nil
-				}
-				if i.logger != nil {
-					i.logger.Info(
-						"requested language not found, falling back to 'en'",
-						zap.String("key", key),
-						zap.String("requested_lang", lang),
-					)
-				}
-			}
+// translate looks up key in the requested language, applying the fallback rules
+// described in CustomTemplateFunctions, and interpolates args. The request r may
+// be nil; if set, it is used to record where missing translations occur and to
+// apply the debug keys mode.
+func (i *I18n) translate(r *http.Request, key, lang string, args []interface{}) string {
+	val, _ := i.translateVariant(r, key, lang, args, nil)
+	return val
+}
 
-			// Replace positional arguments {0}, {1}, etc. with provided arguments
-			if len(args) > 0 {
-				val = i.interpolateTranslations(val, lang, args)
-			}
+// translateCtx is translateVariant for the functions that take the template
+// context, which may be nil. It also records the language of the text in the
+// Content-Language header.
+func (i *I18n) translateCtx(ctx *templates.TemplateContext, key, lang string, args []interface{}, variant variantSelector) string {
+	val, textLang := i.translateVariant(requestOf(ctx), key, lang, args, variant)
+	i.recordContentLanguage(ctx, textLang)
+	return val
+}
 
-			return val, nil
-		},
+// translateVariant is translate for entries with variants: if the translation
+// has forms, variant selects one for the language it is taken from. If variant
+// is nil or selects a form the entry doesn't have, the "other" form is used.
+// Besides the translation, it returns the language of its text, as lookup does.
+func (i *I18n) translateVariant(r *http.Request, key, lang string, args []interface{}, variant variantSelector) (string, string) {
+	// Edits of pseudo-localized text go to the source language, the only
+	// one lookup reads for pseudo-locales.
+	editLang := lang
+	if i.Pseudo.locale(lang) {
+		editLang = i.Pseudo.sourceLang()
+	}
+	val, textLang := i.lookup(r, key, lang, args, variant)
+	return i.DebugKeys.decorate(r, key, editLang, val), textLang
+}
+
+// lookup implements translateVariant without the debug keys mode. It returns
+// the translation and the language its text is in: the requested language,
+// 'en' if the translation fell back to English, or the pseudo-locale for
+// pseudo-localized text. If the key itself is returned, the language is empty.
+func (i *I18n) lookup(r *http.Request, key, lang string, args []interface{}, variant variantSelector) (string, string) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// Pseudo-locales are generated from the source language
+	pseudoLang := ""
+	if i.Pseudo.locale(lang) {
+		pseudoLang, lang = lang, i.Pseudo.sourceLang()
+	}
+
+	metricLang := i.metricLang(lang)
+	i18nMetrics.lookups.WithLabelValues(metricLang).Inc()
+
+	// Check if the translation key exists
+	entry, ok := i.translations[key]
+	if !ok {
+		// Log a warning and return the key itself as a sensible fallback
+		i18nMetrics.misses.WithLabelValues(metricLang).Inc()
+		i.missing.record(r, missingKindKey, key, lang)
+		i.logLookup(i.missingLogLevel(), "translation key not found, using key as fallback", key, "")
+		return key, ""
+	}
+
+	// If requested language exists, use it
+	textLang := lang
+	val, ok := entry[lang]
+	if !ok {
+		textLang = "en"
+		i.missing.record(r, missingKindLanguage, key, lang)
+
+		// Try English as fallback language
+		val, ok = entry["en"]
+		if !ok {
+			// Final fallback: log warning and return key
+			i18nMetrics.misses.WithLabelValues(metricLang).Inc()
+			i.logLookup(i.missingLogLevel(), "no translation for requested language or 'en', using key as fallback", key, lang)
+			return key, ""
+		}
+		i18nMetrics.fallbacks.WithLabelValues(metricLang).Inc()
+		if i.LogFallbacks == nil || *i.LogFallbacks {
+			i.logLookup(zapcore.InfoLevel, "requested language not found, falling back to 'en'", key, lang)
+		}
+	}
+
+	if forms, ok := i.variants[key][textLang]; ok && variant != nil {
+		val = forms.pick(variant(textLang))
 	}
+
+	// Pseudo-localize the template before interpolation, so arguments stay intact
+	contentLang := textLang
+	if pseudoLang != "" {
+		val = i.Pseudo.transform(pseudoLang, val)
+		contentLang = pseudoLang
+	}
+
+	// Replace positional arguments {0}, {1}, etc. with provided arguments
+	if len(args) > 0 {
+		val = i.interpolateTranslations(r, key, val, lang, args, i.isolateArgs(textLang))
+	}
+
+	return val, contentLang
 }
 
 // interpolateTranslations replaces placeholders in the template string with argument values.
-// Placeholders are in the form {0}, {1}, etc., indexed from 0.
+// Placeholders are in the form {0}, {1}, etc., indexed from 0. A placeholder may name a
+// format and style, e.g. {0, number, max=2}, to format the argument for lang; see argFormatters.
 //
 // Argument handling:
 //   - Arguments starting with "i18n:" prefix are treated as translation keys and translated recursively
 //   - Other string arguments are used as-is
 //   - Non-string arguments are converted to strings using fmt.Sprint
 //
+// If isolate is true, each argument is wrapped in the bidi isolates FSI and PDI.
+// key is the translation key of tmpl, used when logging arguments that cannot
+// be formatted.
+//
 // Example:
 //
 //	Template: "Error: {0} at {1}"
 //	Args: []interface{}{"i18n:system", "i18n:module"}
 //	Result: "Error: System at Module" (after translation)
-func (i *I18n) interpolateTranslations(tmpl string, lang string, args []interface{}) string {
-	// Regex to find placeholders like {0}, {1}, etc.
-	re := regexp.MustCompile(`\{(\d+)\}`)
-
-	result := re.ReplaceAllStringFunc(tmpl, func(match string) string {
-		// Extract the number from {N}
-		numStr := strings.Trim(match, "{}")
-		idx, err := strconv.Atoi(numStr)
+func (i *I18n) interpolateTranslations(r *http.Request, key, tmpl, lang string, args []interface{}, isolate bool) string {
+	result := placeholderRegexp.ReplaceAllStringFunc(tmpl, func(match string) string {
+		// Extract the number, format and style from {N, format, style}
+		groups := placeholderRegexp.FindStringSubmatch(match)
+		idx, err := strconv.Atoi(groups[1])
 		if err != nil || idx >= len(args) {
 			return match // Return unchanged if invalid index
 		}
 
-		arg := args[idx]
-
-		// If the argument is a string, check if it should be translated
-		if str, ok := arg.(string); ok {
-			// Check for i18n: prefix indicating a translation key
-			if strings.HasPrefix(str, "i18n:") {
-				translationKey := strings.TrimPrefix(str, "i18n:")
-				entry, exists := i.translations[translationKey]
-				if exists {
-					// Try requested language first
-					if val, ok := entry[lang]; ok {
-						return val
-					}
-					// Fallback to English
-					if val, ok := entry["en"]; ok {
-						return val
-					}
-				}
-				// If no translation found, return the key as fallback
-				return translationKey
-			}
-			// No i18n: prefix, return string as-is
-			return str
+		var val string
+		if groups[2] != "" {
+			val = i.formatPlaceholderArg(r, key, lang, args[idx], groups[2], strings.TrimSpace(groups[3]))
+		} else {
+			val = i.formatArg(r, lang, args[idx])
 		}
-
-		// For other types, convert to string representation
-		return fmt.Sprint(arg)
+		if isolate {
+			return bidiIsolate(val)
+		}
+		return val
 	})
 
 	return result
 }
 
+// formatArg returns the text of an interpolated argument, translating
+// arguments with the "i18n:" prefix into lang.
+func (i *I18n) formatArg(r *http.Request, lang string, arg interface{}) string {
+	// If the argument is a string, check if it should be translated
+	if str, ok := arg.(string); ok {
+		// Check for i18n: prefix indicating a translation key
+		if strings.HasPrefix(str, "i18n:") {
+			translationKey := strings.TrimPrefix(str, "i18n:")
+			entry, exists := i.translations[translationKey]
+			if exists {
+				// Try requested language first
+				if val, ok := entry[lang]; ok {
+					return val
+				}
+				i.missing.record(r, missingKindLanguage, translationKey, lang)
+				// Fallback to English
+				if val, ok := entry["en"]; ok {
+					return val
+				}
+			} else {
+				i.missing.record(r, missingKindKey, translationKey, lang)
+			}
+			// If no translation found, return the key as fallback
+			i18nMetrics.nestedMisses.WithLabelValues(i.metricLang(lang)).Inc()
+			return translationKey
+		}
+		// No i18n: prefix, return string as-is
+		return str
+	}
+
+	// For other types, convert to string representation
+	return fmt.Sprint(arg)
+}
+
 // loadDictionary reads and parses the JSON translation dictionary file.
 // The file must contain a JSON object with the structure:
 // map[translationKey]map[languageCode]translatedText
+// The loaded translations are only replaced if the file could be parsed.
 func (i *I18n) loadDictionary() error {
-	file, err := os.Open(i.DictFile)
+	data, err := os.ReadFile(i.DictFile)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return fmt.Errorf("dictionary file not found: %s", i.DictFile)
 		}
 		return err
 	}
-	defer file.Close()
 
-	decoder := json.NewDecoder(file)
+	decoder := json.NewDecoder(bytes.NewReader(data))
 
-	if err := decoder.Decode(&i.translations); err != nil {
+	var raw map[string]map[string]json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("failed to parse JSON dictionary: %w", err)
+	}
+	translations, variants, err := parseDictionary(raw)
+	if err != nil {
 		return fmt.Errorf("failed to parse JSON dictionary: %w", err)
 	}
+	i.translations = translations
+	i.variants = variants
+	i.dictSum = sha256.Sum256(data)
 
 	return nil
 }
 
+// newDictionary loads dictFile for internal use by another handler, such as
+// i18n_bundle or i18n_negotiate. Unlike Provision, it does not register the
+// instance with the admin API, log coverage or record metrics; the templates
+// extension using the same file already does that. The caller registers the
+// result with internalDictionaries so that it is reloaded after edits.
+func newDictionary(ctx caddy.Context, dictFile string) (*I18n, error) {
+	i := &I18n{
+		DictFile: dictFile,
+		logger:   ctx.Logger(),
+		mu:       &sync.RWMutex{},
+		internal: true,
+	}
+	if err := i.loadDictionary(); err != nil {
+		return nil, fmt.Errorf("failed to load i18n dictionary: %w", err)
+	}
+	i.countLanguages()
+	return i, nil
+}
+
+// reloadDictionary reads the dictionary file again and replaces the loaded
+// translations while holding the write lock. If the file cannot be read or
+// parsed, the previous translations stay active.
+func (i *I18n) reloadDictionary() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	err := i.loadDictionary()
+	if i.internal {
+		if err == nil {
+			i.countLanguages()
+		}
+	} else {
+		i.recordDictionaryLoad(err)
+	}
+	if err != nil {
+		return err
+	}
+	if i.logger != nil {
+		i.logger.Info("i18n dictionary reloaded", zap.String("dict_file", i.DictFile))
+	}
+	return nil
+}
+
 // Interface guards ensure that I18n implements the required interfaces.
 var (
 	_ caddy.Provisioner         = (*I18n)(nil)
+	_ caddy.CleanerUpper        = (*I18n)(nil)
 	_ templates.CustomFunctions = (*I18n)(nil)
 )
@@ -541,3 +541,37 @@ func TestI18nCaddyModule(t *testing.T) {
 		t.Fatal("expected New function to be set")
 	}
 }
+
+func TestI18nReloadDictionary(t *testing.T) {
+	dictFile := createTestDictFile(t, `{"hello": {"en": "Hello"}}`)
+
+	i18n := &I18n{DictFile: dictFile}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	if err := os.WriteFile(dictFile, []byte(`{"hello": {"en": "Hi"}}`), 0644); err != nil {
+		t.Fatalf("failed to update dict file: %v", err)
+	}
+	if err := i18n.reloadDictionary(); err != nil {
+		t.Fatalf("reloadDictionary failed: %v", err)
+	}
+	if i18n.translations["hello"]["en"] != "Hi" {
+		t.Errorf("expected reloaded translation 'Hi', got %q", i18n.translations["hello"]["en"])
+	}
+
+	// A broken file must not replace the loaded translations
+	if err := os.WriteFile(dictFile, []byte(`{broken`), 0644); err != nil {
+		t.Fatalf("failed to update dict file: %v", err)
+	}
+	if err := i18n.reloadDictionary(); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+	if i18n.translations["hello"]["en"] != "Hi" {
+		t.Errorf("expected previous translations to stay active, got %v", i18n.translations)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// List types as defined by CLDR: "and" joins a conjunction, "or" a
+// disjunction and "unit" a list of measurements such as "3 ft, 7 in".
+const (
+	listAnd  = "and"
+	listOr   = "or"
+	listUnit = "unit"
+)
+
+// listPatterns holds the CLDR patterns of a list type. two joins lists of
+// two elements; longer lists use start for the first two elements, end for
+// the last two and middle for the ones in between.
+type listPatterns struct {
+	two, start, middle, end string
+}
+
+// commaList returns the patterns of list types that separate elements with
+// commas and the last two with conjunction, e.g. " und ".
+func commaList(conjunction string) listPatterns {
+	return listPatterns{
+		two:    "{0}" + conjunction + "{1}",
+		start:  "{0}, {1}",
+		middle: "{0}, {1}",
+		end:    "{0}" + conjunction + "{1}",
+	}
+}
+
+// listData contains the CLDR list patterns of the bundled locales, keyed by
+// list type.
+var listData = map[string]map[string]listPatterns{
+	"de": {listAnd: commaList(" und "), listOr: commaList(" oder "), listUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"}},
+	"en": {
+		listAnd:  {"{0} and {1}", "{0}, {1}", "{0}, {1}", "{0}, and {1}"},
+		listOr:   {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0}, or {1}"},
+		listUnit: commaList(", "),
+	},
+	"en-GB": {listAnd: commaList(" and "), listOr: commaList(" or "), listUnit: commaList(", ")},
+	"es":    {listAnd: commaList(" y "), listOr: commaList(" o "), listUnit: commaList(" y ")},
+	"fr":    {listAnd: commaList(" et "), listOr: commaList(" ou "), listUnit: commaList(" et ")},
+	"it":    {listAnd: commaList(" e "), listOr: commaList(" o "), listUnit: commaList(" e ")},
+	"nl":    {listAnd: commaList(" en "), listOr: commaList(" of "), listUnit: commaList(" en ")},
+	"pt":    {listAnd: commaList(" e "), listOr: commaList(" ou "), listUnit: commaList(" e ")},
+}
+
+// parseListType returns the list type of opts, which is empty or one of
+// and, or and unit. It defaults to and.
+func parseListType(opts string) (string, error) {
+	switch opts = strings.TrimSpace(opts); opts {
+	case "":
+		return listAnd, nil
+	case listAnd, listOr, listUnit:
+		return opts, nil
+	}
+	return "", fmt.Errorf("invalid list type %q: must be and, or or unit", opts)
+}
+
+// toList converts a template value to its elements: a slice or an array, or
+// a single value as a list of one.
+func toList(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, fmt.Errorf("cannot format nil as a list")
+	case []interface{}:
+		return v, nil
+	case []string:
+		items := make([]interface{}, len(v))
+		for idx, s := range v {
+			items[idx] = s
+		}
+		return items, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{value}, nil
+	}
+	items := make([]interface{}, rv.Len())
+	for idx := range items {
+		items[idx] = rv.Index(idx).Interface()
+	}
+	return items, nil
+}
+
+// joinList joins items with the patterns of a list type.
+func joinList(items []string, patterns listPatterns) string {
+	apply := func(pattern, first, second string) string {
+		return strings.NewReplacer("{0}", first, "{1}", second).Replace(pattern)
+	}
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return apply(patterns.two, items[0], items[1])
+	}
+	n := len(items)
+	result := apply(patterns.end, items[n-2], items[n-1])
+	for idx := n - 3; idx > 0; idx-- {
+		result = apply(patterns.middle, items[idx], result)
+	}
+	return apply(patterns.start, items[0], result)
+}
+
+// formatList joins the elements of value with the CLDR list pattern of the
+// list type in opts for lang, e.g. "A, B und C". Elements with the "i18n:"
+// prefix are translated first, as interpolated arguments are. The caller
+// must hold i.mu for reading.
+func (i *I18n) formatList(r *http.Request, value interface{}, lang, opts string) (string, error) {
+	listType, err := parseListType(opts)
+	if err != nil {
+		return "", err
+	}
+	items, err := toList(value)
+	if err != nil {
+		return "", err
+	}
+	texts := make([]string, len(items))
+	for idx, item := range items {
+		texts[idx] = i.formatArg(r, lang, item)
+	}
+	return joinList(texts, lookupLocale(listData, lang)[listType]), nil
+}
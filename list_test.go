@@ -0,0 +1,143 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestListI18n(t *testing.T) *I18n {
+	t.Helper()
+	return &I18n{
+		translations: map[string]map[string]string{
+			"perm.read":  {"de": "Lesen", "en": "read"},
+			"perm.write": {"de": "Schreiben", "en": "write"},
+			"perm.admin": {"en": "administer"},
+			"perms.granted": {
+				"de": "Du darfst: {0, list}",
+				"en": "You may {0, list, or}.",
+			},
+		},
+		mu:     new(sync.RWMutex),
+		logger: zaptest.NewLogger(t),
+	}
+}
+
+func TestFormatList(t *testing.T) {
+	i18n := newTestListI18n(t)
+	abc := []string{"A", "B", "C"}
+
+	tests := []struct {
+		items    interface{}
+		lang     string
+		opts     string
+		expected string
+	}{
+		{abc, "de", "", "A, B und C"},
+		{abc, "en", "", "A, B, and C"},
+		{abc, "en", "or", "A, B, or C"},
+		{abc, "en", "unit", "A, B, C"},
+		{abc, "en-GB", "", "A, B and C"},
+		{abc, "en-AU", "and", "A, B, and C"},
+		{abc, "fr", "or", "A, B ou C"},
+		{abc, "es", "", "A, B y C"},
+		{abc, "de-CH", "unit", "A, B und C"},
+		{abc, "sv", "", "A, B, and C"},
+		{[]string{"A", "B", "C", "D"}, "de", "", "A, B, C und D"},
+		{[]string{"A", "B"}, "en", "", "A and B"},
+		{[]string{"A", "B"}, "en", "unit", "A, B"},
+		{[]string{"A", "B"}, "nl", "or", "A of B"},
+		{[]string{"A"}, "en", "", "A"},
+		{[]string{}, "en", "", ""},
+		{"A", "en", "", "A"},
+		{[]interface{}{1, 2.5, "drei"}, "de", "", "1, 2.5 und drei"},
+		{[]int{1, 2, 3}, "it", "", "1, 2 e 3"},
+		{[]interface{}{"i18n:perm.read", "i18n:perm.write", "i18n:perm.admin"}, "de", "", "Lesen, Schreiben und administer"},
+	}
+
+	for _, tt := range tests {
+		result, err := i18n.formatList(nil, tt.items, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatList(%v, %q, %q): unexpected error: %v", tt.items, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatList(%v, %q, %q): expected %q, got %q", tt.items, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatListErrors(t *testing.T) {
+	i18n := newTestListI18n(t)
+	if _, err := i18n.formatList(nil, []string{"A"}, "en", "xor"); err == nil {
+		t.Error("expected error for invalid list type, got nil")
+	}
+	if _, err := i18n.formatList(nil, nil, "en", ""); err == nil {
+		t.Error("expected error for nil list, got nil")
+	}
+}
+
+func TestListPlaceholder(t *testing.T) {
+	i18n := newTestListI18n(t)
+	arg := []interface{}{[]string{"i18n:perm.read", "i18n:perm.write"}}
+
+	if result := i18n.translate(nil, "perms.granted", "de", arg); result != "Du darfst: Lesen und Schreiben" {
+		t.Errorf("unexpected German result %q", result)
+	}
+	if result := i18n.translate(nil, "perms.granted", "en", arg); result != "You may read or write." {
+		t.Errorf("unexpected English result %q", result)
+	}
+}
+
+func TestI18nListFunction(t *testing.T) {
+	i18n := newTestListI18n(t)
+	listFunc := i18n.CustomTemplateFunctions()["i18nList"].(func(interface{}, string, ...string) (string, error))
+
+	if result, err := listFunc([]interface{}{"i18n:perm.read", "i18n:perm.write"}, "en", "or"); err != nil || result != "read or write" {
+		t.Errorf("expected 'read or write', got %q (%v)", result, err)
+	}
+	if _, err := listFunc([]string{"A"}, "en", "both"); err == nil {
+		t.Error("expected error for invalid list type, got nil")
+	}
+}
+
+func TestListInCaddyTemplate(t *testing.T) {
+	i18n := newTestListI18n(t)
+
+	// Runs the documented examples with the functions of Caddy's templates
+	// handler, including sprig's list.
+	tplCtx := &templates.TemplateContext{CustomFuncs: []template.FuncMap{i18n.CustomTemplateFunctions()}}
+	tpl, err := tplCtx.NewTemplate("page").Parse(`{{ i18nList (list "Hosting" "Domains" "E-Mail") "de" }}` +
+		`|{{ i18nList (list "i18n:perm.read" "i18n:perm.write") "en" "or" }}` +
+		`|{{ i18nTranslate "perms.granted" "de" (list "i18n:perm.read" "i18n:perm.write") }}`)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, tplCtx); err != nil {
+		t.Fatalf("failed to execute template: %v", err)
+	}
+	expected := "Hosting, Domains und E-Mail|read or write|Du darfst: Lesen und Schreiben"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
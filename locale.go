@@ -0,0 +1,45 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import "golang.org/x/text/language"
+
+// lookupLocale returns the entry of table for lang. The module bundles the
+// CLDR data that golang.org/x/text doesn't provide, such as currency patterns
+// and calendars, for a set of locales. lookupLocale tries lang as given, in
+// its canonical form and its base language, e.g. "de-AT", then "de". If none
+// of them is in table, the "en" entry is returned.
+func lookupLocale[T any](table map[string]T, lang string) T {
+	data, _ := findLocale(table, lang)
+	return data
+}
+
+// findLocale is like lookupLocale, but also reports whether the entry was
+// found for lang rather than being the "en" fallback.
+func findLocale[T any](table map[string]T, lang string) (T, bool) {
+	if data, ok := table[lang]; ok {
+		return data, true
+	}
+	tag := language.Make(lang)
+	if data, ok := table[tag.String()]; ok {
+		return data, true
+	}
+	if base, conf := tag.Base(); conf != language.No {
+		if data, ok := table[base.String()]; ok {
+			return data, true
+		}
+	}
+	return table["en"], false
+}
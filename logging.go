@@ -0,0 +1,187 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxLogDedupEntries is the maximum number of messages the log deduplicator
+// tracks. When it is reached, messages whose interval has elapsed are
+// forgotten first, then the least recently written ones.
+const maxLogDedupEntries = 10000
+
+// logDedupID identifies a deduplicated log message.
+type logDedupID struct {
+	msg, key, lang string
+}
+
+// logDedupState tracks when a message was last written and how many
+// occurrences were suppressed since.
+type logDedupState struct {
+	id         logDedupID
+	level      zapcore.Level
+	last       time.Time
+	suppressed uint64
+}
+
+// logDeduper limits identical lookup log messages to one per interval.
+// A nil *logDeduper lets every message through.
+type logDeduper struct {
+	mu       sync.Mutex
+	interval time.Duration
+	max      int
+
+	// entries maps messages to their element in order, which holds the
+	// tracked messages ordered by the time they were last written, oldest
+	// first.
+	entries map[logDedupID]*list.Element
+	order   *list.List
+}
+
+// newLogDeduper creates a deduplicator with the given interval.
+func newLogDeduper(interval time.Duration) *logDeduper {
+	return &logDeduper{
+		interval: interval,
+		max:      maxLogDedupEntries,
+		entries:  make(map[logDedupID]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// allow reports whether the message should be written now. If so, it also
+// returns the number of occurrences suppressed since it was last written.
+// Messages forgotten to make room for this one are returned if they have
+// suppressed occurrences, so that the caller can still report them.
+func (d *logDeduper) allow(level zapcore.Level, msg, key, lang string) (ok bool, suppressed uint64, evicted []logDedupState) {
+	if d == nil {
+		return true, 0, nil
+	}
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := logDedupID{msg: msg, key: key, lang: lang}
+	elem, exists := d.entries[id]
+	if exists {
+		state := elem.Value.(*logDedupState)
+		if now.Sub(state.last) < d.interval {
+			state.suppressed++
+			return false, 0, nil
+		}
+		suppressed = state.suppressed
+		state.last = now
+		state.suppressed = 0
+		d.order.MoveToBack(elem)
+		return true, suppressed, nil
+	}
+
+	if len(d.entries) >= d.max {
+		evicted = d.prune(now)
+	}
+	d.entries[id] = d.order.PushBack(&logDedupState{id: id, level: level, last: now})
+	return true, 0, evicted
+}
+
+// prune forgets all messages whose interval has elapsed and, if the
+// deduplicator is still full, the least recently written messages until
+// there is room for one more. It returns the forgotten messages that have
+// suppressed occurrences. The caller must hold d.mu.
+func (d *logDeduper) prune(now time.Time) []logDedupState {
+	var evicted []logDedupState
+	for elem := d.order.Front(); elem != nil; elem = d.order.Front() {
+		state := elem.Value.(*logDedupState)
+		if len(d.entries) < d.max && now.Sub(state.last) < d.interval {
+			break
+		}
+		if state.suppressed > 0 {
+			evicted = append(evicted, *state)
+		}
+		d.order.Remove(elem)
+		delete(d.entries, state.id)
+	}
+	return evicted
+}
+
+// logLookup writes a lookup-related log message at the given level, unless an
+// identical message for the same key and language was written within the
+// configured missing_log_interval. fields are added to the message but don't
+// distinguish it from others.
+func (i *I18n) logLookup(level zapcore.Level, msg, key, lang string, fields ...zap.Field) {
+	if i.logger == nil {
+		return
+	}
+	ce := i.logger.Check(level, msg)
+	if ce == nil {
+		return
+	}
+
+	ok, suppressed, evicted := i.logDedup.allow(level, msg, key, lang)
+	for _, state := range evicted {
+		if ce := i.logger.Check(state.level, state.id.msg); ce != nil {
+			ce.Write(lookupLogFields(state.id.key, state.id.lang, state.suppressed)...)
+		}
+	}
+	if !ok {
+		return
+	}
+	ce.Write(append(lookupLogFields(key, lang, suppressed), fields...)...)
+}
+
+// lookupLogFields returns the fields of a lookup-related log message.
+func lookupLogFields(key, lang string, suppressed uint64) []zap.Field {
+	fields := []zap.Field{zap.String("key", key)}
+	if lang != "" {
+		fields = append(fields, zap.String("requested_lang", lang))
+	}
+	if suppressed > 0 {
+		fields = append(fields, zap.Uint64("suppressed", suppressed))
+	}
+	return fields
+}
+
+// missingLogLevel returns the configured level for missing-translation logs.
+func (i *I18n) missingLogLevel() zapcore.Level {
+	level, err := parseMissingLogLevel(i.MissingLogLevel)
+	if err != nil {
+		return zapcore.WarnLevel
+	}
+	return level
+}
+
+// parseMissingLogLevel parses the missing_log_level option. An empty string
+// selects the default level, warn.
+func parseMissingLogLevel(s string) (zapcore.Level, error) {
+	switch s {
+	case "":
+		return zapcore.WarnLevel, nil
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	}
+	return zapcore.InvalidLevel, fmt.Errorf("invalid missing_log_level %q: must be one of debug, info, warn, error", s)
+}
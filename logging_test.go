@@ -0,0 +1,203 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogDedupSuppressesRepeatedMisses(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"en": "Hello"},
+		},
+		logDedup: newLogDeduper(time.Hour),
+		logger:   zap.New(core),
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	for range 5 {
+		i18n.translate(nil, "missing", "de", nil)
+		i18n.translate(nil, "hello", "de", nil)
+	}
+	i18n.translate(nil, "hello", "fr", nil)
+
+	if n := logs.FilterMessage("translation key not found, using key as fallback").Len(); n != 1 {
+		t.Errorf("expected 1 missing-key log, got %d", n)
+	}
+	if n := logs.FilterMessage("requested language not found, falling back to 'en'").Len(); n != 2 {
+		t.Errorf("expected 2 fallback logs (de and fr), got %d", n)
+	}
+}
+
+func TestLogDedupSuppressesRepeatedFormatErrors(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"amount": {"en": "Amount: {0, number}"},
+			"other":  {"en": "Other: {0, fancy}"},
+		},
+		logDedup: newLogDeduper(time.Hour),
+		logger:   zap.New(core),
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	for range 5 {
+		if got := i18n.translate(nil, "amount", "en", []interface{}{"abc"}); got != "Amount: abc" {
+			t.Fatalf("expected the argument as is, got %q", got)
+		}
+		i18n.translate(nil, "other", "en", []interface{}{1})
+	}
+
+	entries := logs.FilterMessage("cannot format placeholder argument, using argument as is").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 format error log, got %d", len(entries))
+	}
+	if fields := entries[0].ContextMap(); fields["key"] != "amount" || fields["format"] != "number" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if n := logs.FilterMessage("unknown placeholder format, using argument as is").Len(); n != 1 {
+		t.Errorf("expected 1 unknown format log, got %d", n)
+	}
+
+	// Without a logger, format errors are not logged at all
+	i18n.logger = nil
+	if got := i18n.translate(nil, "amount", "en", []interface{}{"abc"}); got != "Amount: abc" {
+		t.Errorf("expected the argument as is without a logger, got %q", got)
+	}
+}
+
+func TestLogDedupReportsSuppressedCount(t *testing.T) {
+	d := newLogDeduper(time.Hour)
+
+	if ok, _, _ := d.allow(zapcore.WarnLevel, "msg", "key", "de"); !ok {
+		t.Fatal("expected first message to be allowed")
+	}
+	for range 3 {
+		if ok, _, _ := d.allow(zapcore.WarnLevel, "msg", "key", "de"); ok {
+			t.Fatal("expected repeated message to be suppressed")
+		}
+	}
+
+	// Pretend the interval has elapsed
+	d.entries[logDedupID{msg: "msg", key: "key", lang: "de"}].Value.(*logDedupState).last = time.Now().Add(-2 * time.Hour)
+
+	ok, suppressed, _ := d.allow(zapcore.WarnLevel, "msg", "key", "de")
+	if !ok {
+		t.Fatal("expected message to be allowed after the interval")
+	}
+	if suppressed != 3 {
+		t.Errorf("expected 3 suppressed occurrences, got %d", suppressed)
+	}
+}
+
+func TestLogDedupEvictsOldestAndReportsSuppressed(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	i18n := &I18n{
+		translations: map[string]map[string]string{},
+		logDedup:     newLogDeduper(time.Hour),
+		logger:       zap.New(core),
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logDedup.max = 2
+
+	i18n.translate(nil, "first", "de", nil)
+	i18n.translate(nil, "first", "de", nil)
+	i18n.translate(nil, "second", "de", nil)
+	i18n.translate(nil, "third", "de", nil)
+
+	if n := len(i18n.logDedup.entries); n != 2 {
+		t.Errorf("expected the cap of 2 entries to be enforced, got %d", n)
+	}
+	if _, ok := i18n.logDedup.entries[logDedupID{msg: "translation key not found, using key as fallback", key: "first", lang: "de"}]; ok {
+		t.Error("expected the oldest entry to be evicted")
+	}
+
+	var reported bool
+	for _, entry := range logs.FilterField(zap.String("key", "first")).All() {
+		if entry.ContextMap()["suppressed"] == uint64(1) {
+			reported = true
+		}
+	}
+	if !reported {
+		t.Error("expected the suppressed count of the evicted entry to be logged")
+	}
+}
+
+func TestLogDedupNilAllowsEverything(t *testing.T) {
+	var d *logDeduper
+	for range 3 {
+		if ok, _, _ := d.allow(zapcore.WarnLevel, "msg", "key", "de"); !ok {
+			t.Fatal("expected nil deduplicator to allow every message")
+		}
+	}
+}
+
+func TestLogLevelAndFallbackOption(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logFallbacks := false
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"en": "Hello"},
+		},
+		MissingLogLevel: "debug",
+		LogFallbacks:    &logFallbacks,
+		logger:          zap.New(core),
+	}
+	i18n.mu = new(sync.RWMutex)
+
+	i18n.translate(nil, "missing", "de", nil)
+	i18n.translate(nil, "hello", "de", nil)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected only the missing-key log, got %d entries", len(entries))
+	}
+	if entries[0].Level != zapcore.DebugLevel {
+		t.Errorf("expected debug level, got %s", entries[0].Level)
+	}
+}
+
+func TestParseMissingLogLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected zapcore.Level
+		wantErr  bool
+	}{
+		{"", zapcore.WarnLevel, false},
+		{"debug", zapcore.DebugLevel, false},
+		{"info", zapcore.InfoLevel, false},
+		{"warn", zapcore.WarnLevel, false},
+		{"error", zapcore.ErrorLevel, false},
+		{"fatal", zapcore.InvalidLevel, true},
+	}
+
+	for _, tt := range tests {
+		level, err := parseMissingLogLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("input %q: unexpected error state: %v", tt.input, err)
+		}
+		if level != tt.expected {
+			t.Errorf("input %q: expected %s, got %s", tt.input, tt.expected, level)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherLangLabel is used as the lang label for languages that do not occur
+// in the dictionary, so that arbitrary user input cannot inflate the number
+// of metric series.
+const otherLangLabel = "other"
+
+// i18nMetrics holds the Prometheus collectors for translation lookups.
+// They are shared by all I18n instances and registered with Caddy's
+// metrics registry during provisioning.
+var i18nMetrics = struct {
+	lookups        *prometheus.CounterVec
+	fallbacks      *prometheus.CounterVec
+	misses         *prometheus.CounterVec
+	nestedMisses   *prometheus.CounterVec
+	reloads        *prometheus.CounterVec
+	reloadFailures *prometheus.CounterVec
+	keys           *prometheus.GaugeVec
+}{
+	lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "lookups_total",
+		Help:      "Number of translation lookups by requested language.",
+	}, []string{"lang"}),
+	fallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "fallbacks_total",
+		Help:      "Number of lookups answered in the default language instead of the requested one.",
+	}, []string{"lang"}),
+	misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "misses_total",
+		Help:      "Number of lookups that returned the key because no translation was found.",
+	}, []string{"lang"}),
+	nestedMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "nested_misses_total",
+		Help:      "Number of i18n: arguments that could not be translated.",
+	}, []string{"lang"}),
+	reloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "dictionary_reloads_total",
+		Help:      "Number of successful dictionary loads that changed the dictionary.",
+	}, []string{"dict_file"}),
+	reloadFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "dictionary_reload_failures_total",
+		Help:      "Number of failed dictionary loads.",
+	}, []string{"dict_file"}),
+	keys: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "caddy",
+		Subsystem: "i18n",
+		Name:      "dictionary_keys",
+		Help:      "Number of translated keys per language in the loaded dictionary.",
+	}, []string{"dict_file", "lang"}),
+}
+
+// registerMetrics registers the i18n collectors with the given registry.
+// Several I18n instances share the same collectors, so duplicate registrations
+// are ignored.
+func registerMetrics(registry *prometheus.Registry) error {
+	if registry == nil {
+		return nil
+	}
+	for _, c := range []prometheus.Collector{
+		i18nMetrics.lookups,
+		i18nMetrics.fallbacks,
+		i18nMetrics.misses,
+		i18nMetrics.nestedMisses,
+		i18nMetrics.reloads,
+		i18nMetrics.reloadFailures,
+		i18nMetrics.keys,
+	} {
+		if err := registry.Register(c); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadedDictionaries remembers, per dictionary file, the checksum and the
+// languages last recorded in the metrics. Several instances load the same
+// file, and a config reload provisions all of them again, so a load is only
+// counted when the file content changed.
+var loadedDictionaries = struct {
+	mu    sync.Mutex
+	files map[string]loadedDictionary
+}{files: make(map[string]loadedDictionary)}
+
+// loadedDictionary is the state recorded for one dictionary file.
+type loadedDictionary struct {
+	sum   [sha256.Size]byte
+	langs map[string]int
+}
+
+// recordDictionaryLoad counts a dictionary load and, on success, updates the
+// number of translated keys per language. Series of languages that were
+// removed from the file are deleted. It must be called with the translations
+// map populated and not yet shared with other goroutines, or with i.mu held.
+func (i *I18n) recordDictionaryLoad(err error) {
+	if err != nil {
+		i18nMetrics.reloadFailures.WithLabelValues(i.DictFile).Inc()
+		return
+	}
+	i.countLanguages()
+
+	loadedDictionaries.mu.Lock()
+	defer loadedDictionaries.mu.Unlock()
+
+	prev, ok := loadedDictionaries.files[i.DictFile]
+	if !ok || prev.sum != i.dictSum {
+		i18nMetrics.reloads.WithLabelValues(i.DictFile).Inc()
+	}
+	for lang := range prev.langs {
+		if _, ok := i.langCounts[lang]; !ok {
+			i18nMetrics.keys.DeleteLabelValues(i.DictFile, lang)
+		}
+	}
+	for lang, n := range i.langCounts {
+		i18nMetrics.keys.WithLabelValues(i.DictFile, lang).Set(float64(n))
+	}
+	loadedDictionaries.files[i.DictFile] = loadedDictionary{sum: i.dictSum, langs: i.langCounts}
+}
+
+// forgetDictionaryMetrics deletes the key count series of a dictionary file
+// that is no longer loaded by any instance.
+func forgetDictionaryMetrics(dictFile string) {
+	loadedDictionaries.mu.Lock()
+	defer loadedDictionaries.mu.Unlock()
+
+	i18nMetrics.keys.DeletePartialMatch(prometheus.Labels{"dict_file": dictFile})
+	delete(loadedDictionaries.files, dictFile)
+}
+
+// countLanguages updates the number of translated keys per language from
+// the loaded translations. The same locking rules as for
+// recordDictionaryLoad apply.
+func (i *I18n) countLanguages() {
+	counts := make(map[string]int)
+	for _, entry := range i.translations {
+		for lang, val := range entry {
+			if val != "" {
+				counts[lang]++
+			}
+		}
+	}
+	i.langCounts = counts
+}
+
+// metricLang returns the lang label for a requested language. Languages not
+// found in the loaded dictionary are reported as "other". The caller must
+// hold i.mu.
+func (i *I18n) metricLang(lang string) string {
+	if i.langCounts == nil {
+		return lang
+	}
+	if _, ok := i.langCounts[lang]; ok {
+		return lang
+	}
+	return otherLangLabel
+}
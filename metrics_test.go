@@ -0,0 +1,182 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsLookups(t *testing.T) {
+	dictFile := createTestDictFile(t, `{
+		"hello": {"de": "Hallo", "en": "Hello"},
+		"bye": {"en": "Goodbye"},
+		"msg": {"de": "Nachricht: {0}", "en": "Message: {0}"}
+	}`)
+
+	i18n := &I18n{DictFile: dictFile}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	lookupsDe := testutil.ToFloat64(i18nMetrics.lookups.WithLabelValues("de"))
+	lookupsOther := testutil.ToFloat64(i18nMetrics.lookups.WithLabelValues(otherLangLabel))
+	fallbacksDe := testutil.ToFloat64(i18nMetrics.fallbacks.WithLabelValues("de"))
+	missesDe := testutil.ToFloat64(i18nMetrics.misses.WithLabelValues("de"))
+	nestedDe := testutil.ToFloat64(i18nMetrics.nestedMisses.WithLabelValues("de"))
+
+	i18n.translate(nil, "hello", "de", nil)
+	i18n.translate(nil, "bye", "de", nil)
+	i18n.translate(nil, "unknown", "de", nil)
+	i18n.translate(nil, "msg", "de", []interface{}{"i18n:unknown"})
+	i18n.translate(nil, "hello", "xx-unknown", nil)
+
+	if got := testutil.ToFloat64(i18nMetrics.lookups.WithLabelValues("de")) - lookupsDe; got != 4 {
+		t.Errorf("expected 4 lookups for de, got %v", got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.lookups.WithLabelValues(otherLangLabel)) - lookupsOther; got != 1 {
+		t.Errorf("expected unknown language to be counted as %q, got %v", otherLangLabel, got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.fallbacks.WithLabelValues("de")) - fallbacksDe; got != 1 {
+		t.Errorf("expected 1 fallback for de, got %v", got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.misses.WithLabelValues("de")) - missesDe; got != 1 {
+		t.Errorf("expected 1 miss for de, got %v", got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.nestedMisses.WithLabelValues("de")) - nestedDe; got != 1 {
+		t.Errorf("expected 1 nested miss for de, got %v", got)
+	}
+}
+
+func TestMetricsDictionaryLoad(t *testing.T) {
+	dictFile := createTestDictFile(t, `{
+		"hello": {"de": "Hallo", "en": "Hello"},
+		"bye": {"de": "", "en": "Goodbye"}
+	}`)
+
+	i18n := &I18n{DictFile: dictFile}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+
+	if got := testutil.ToFloat64(i18nMetrics.reloads.WithLabelValues(dictFile)); got != 1 {
+		t.Errorf("expected 1 dictionary load, got %v", got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.keys.WithLabelValues(dictFile, "en")); got != 2 {
+		t.Errorf("expected 2 keys for en, got %v", got)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.keys.WithLabelValues(dictFile, "de")); got != 1 {
+		t.Errorf("expected 1 key for de, got %v", got)
+	}
+
+	// Another instance loading the unchanged file is not a new load
+	second := &I18n{DictFile: dictFile}
+	if err := second.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.reloads.WithLabelValues(dictFile)); got != 1 {
+		t.Errorf("expected unchanged file not to be counted, got %v loads", got)
+	}
+
+	// Languages removed from the file lose their series
+	if err := os.WriteFile(dictFile, []byte(`{"hello": {"en": "Hello"}}`), 0o644); err != nil {
+		t.Fatalf("failed to update dict file: %v", err)
+	}
+	if err := i18n.reloadDictionary(); err != nil {
+		t.Fatalf("reloadDictionary failed: %v", err)
+	}
+	if err := second.reloadDictionary(); err != nil {
+		t.Fatalf("reloadDictionary failed: %v", err)
+	}
+	if got := testutil.ToFloat64(i18nMetrics.reloads.WithLabelValues(dictFile)); got != 2 {
+		t.Errorf("expected 2 dictionary loads after the change, got %v", got)
+	}
+	if langs := keySeriesLangs(t, dictFile); !slices.Equal(langs, []string{"en"}) {
+		t.Errorf("expected only the en series after removing de, got %v", langs)
+	}
+
+	// The series disappear with the last instance using the file
+	second.Cleanup()
+	if langs := keySeriesLangs(t, dictFile); len(langs) != 1 {
+		t.Errorf("expected series to stay while an instance uses the file, got %v", langs)
+	}
+	i18n.Cleanup()
+	if langs := keySeriesLangs(t, dictFile); len(langs) != 0 {
+		t.Errorf("expected series to be removed after cleanup, got %v", langs)
+	}
+
+	broken := &I18n{DictFile: createTestDictFile(t, `{invalid json}`)}
+	broken.logger = zaptest.NewLogger(t)
+	if err := broken.Provision(stubCaddyCtx); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+	if got := testutil.ToFloat64(i18nMetrics.reloadFailures.WithLabelValues(broken.DictFile)); got != 1 {
+		t.Errorf("expected 1 dictionary load failure, got %v", got)
+	}
+}
+
+func TestRegisterMetricsTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	if err := registerMetrics(registry); err != nil {
+		t.Fatalf("first registration failed: %v", err)
+	}
+	if err := registerMetrics(registry); err != nil {
+		t.Errorf("duplicate registration should be ignored, got: %v", err)
+	}
+	if err := registerMetrics(nil); err != nil {
+		t.Errorf("nil registry should be ignored, got: %v", err)
+	}
+}
+
+// keySeriesLangs returns the sorted lang labels of the dictionary_keys series
+// for dictFile.
+func keySeriesLangs(t *testing.T, dictFile string) []string {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(i18nMetrics.keys)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	var langs []string
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["dict_file"] == dictFile {
+				langs = append(langs, labels["lang"])
+			}
+		}
+	}
+	slices.Sort(langs)
+	return langs
+}
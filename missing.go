@@ -0,0 +1,223 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxMissingKeys is the number of distinct misses kept when
+	// max_missing_keys is not configured.
+	defaultMaxMissingKeys = 1000
+
+	// maxMissingPaths is the number of distinct request paths kept per miss.
+	maxMissingPaths = 10
+)
+
+// Kinds of translation misses recorded by the missing-key collector.
+const (
+	// missingKindKey means the translation key does not exist at all.
+	missingKindKey = "key"
+
+	// missingKindLanguage means the key exists but has no translation
+	// for the requested language.
+	missingKindLanguage = "language"
+)
+
+// MissingEntry describes a translation key, or a key/language pair, that was
+// looked up at runtime but is missing from the dictionary.
+type MissingEntry struct {
+	// Kind is "key" if the key does not exist, or "language" if the key exists
+	// but has no translation for Language.
+	Kind string `json:"kind"`
+
+	// Key is the translation key that was looked up.
+	Key string `json:"key"`
+
+	// Language is the requested language. It is empty for missing keys.
+	Language string `json:"lang,omitempty"`
+
+	// Hits is the number of lookups that ran into this miss.
+	Hits uint64 `json:"hits"`
+
+	// FirstSeen and LastSeen are the times of the first and latest miss.
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	// Paths lists up to 10 distinct request paths the miss occurred on.
+	// Paths are only known for request-aware lookups such as i18nTranslateCtx.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// MissingReport is the list of runtime misses collected for one dictionary.
+type MissingReport struct {
+	// DictFile is the dictionary file the misses were collected for.
+	DictFile string `json:"dict_file"`
+
+	// Dropped is the number of misses not recorded because the collector was full.
+	Dropped uint64 `json:"dropped"`
+
+	// Entries holds the recorded misses, most frequent first.
+	Entries []MissingEntry `json:"entries"`
+}
+
+// missingID identifies a recorded miss.
+type missingID struct {
+	kind, key, lang string
+}
+
+// missingKeys is a bounded, in-memory registry of translation misses.
+// A nil *missingKeys is valid and records nothing.
+type missingKeys struct {
+	mu      sync.Mutex
+	max     int
+	dropped uint64
+	entries map[missingID]*MissingEntry
+}
+
+// newMissingKeys creates a collector that keeps at most max distinct misses.
+func newMissingKeys(max int) *missingKeys {
+	return &missingKeys{
+		max:     max,
+		entries: make(map[missingID]*MissingEntry),
+	}
+}
+
+// record counts a miss. For missing keys, lang is ignored so that all
+// languages share one entry. If r is not nil, its path is remembered.
+func (m *missingKeys) record(r *http.Request, kind, key, lang string) {
+	if m == nil {
+		return
+	}
+	if kind == missingKindKey {
+		lang = ""
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := missingID{kind: kind, key: key, lang: lang}
+	entry, ok := m.entries[id]
+	if !ok {
+		if len(m.entries) >= m.max {
+			m.dropped++
+			return
+		}
+		entry = &MissingEntry{Kind: kind, Key: key, Language: lang, FirstSeen: now}
+		m.entries[id] = entry
+	}
+	entry.Hits++
+	entry.LastSeen = now
+
+	if r != nil && len(entry.Paths) < maxMissingPaths {
+		for _, p := range entry.Paths {
+			if p == r.URL.Path {
+				return
+			}
+		}
+		entry.Paths = append(entry.Paths, r.URL.Path)
+	}
+}
+
+// report returns a copy of the recorded misses, most frequent first.
+func (m *missingKeys) report() (entries []MissingEntry, dropped uint64) {
+	entries = []MissingEntry{}
+	if m == nil {
+		return entries, 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.entries {
+		e := *entry
+		e.Paths = append([]string(nil), entry.Paths...)
+		entries = append(entries, e)
+	}
+	sortMissingEntries(entries)
+	return entries, m.dropped
+}
+
+// sortMissingEntries orders entries by hits, most frequent first, then by
+// key and language.
+func sortMissingEntries(entries []MissingEntry) {
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].Hits != entries[b].Hits {
+			return entries[a].Hits > entries[b].Hits
+		}
+		if entries[a].Key != entries[b].Key {
+			return entries[a].Key < entries[b].Key
+		}
+		return entries[a].Language < entries[b].Language
+	})
+}
+
+// reset forgets all recorded misses.
+func (m *missingKeys) reset() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[missingID]*MissingEntry)
+	m.dropped = 0
+}
+
+// mergeMissingReports combines the runtime misses collected by several
+// instances loading the same dictionary file into one report. Hits and
+// dropped misses are summed, and the paths of the earliest miss come first.
+func mergeMissingReports(group []*I18n) MissingReport {
+	merged := MissingReport{DictFile: group[0].DictFile, Entries: []MissingEntry{}}
+
+	byID := make(map[missingID][]MissingEntry)
+	for _, i := range group {
+		entries, dropped := i.missing.report()
+		merged.Dropped += dropped
+		for _, e := range entries {
+			id := missingID{kind: e.Kind, key: e.Key, lang: e.Language}
+			byID[id] = append(byID[id], e)
+		}
+	}
+
+	for _, entries := range byID {
+		sort.Slice(entries, func(a, b int) bool { return entries[a].FirstSeen.Before(entries[b].FirstSeen) })
+
+		e := entries[0]
+		e.Hits = 0
+		e.Paths = nil
+		for _, other := range entries {
+			e.Hits += other.Hits
+			if other.LastSeen.After(e.LastSeen) {
+				e.LastSeen = other.LastSeen
+			}
+			for _, p := range other.Paths {
+				if len(e.Paths) < maxMissingPaths && !slices.Contains(e.Paths, p) {
+					e.Paths = append(e.Paths, p)
+				}
+			}
+		}
+		merged.Entries = append(merged.Entries, e)
+	}
+	sortMissingEntries(merged.Entries)
+	return merged
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMissingKeysRecordsKeysAndLanguages(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello":   {"en": "Hello"},
+			"account": {"en": "Account"},
+			"msg":     {"de": "Nachricht: {0}", "en": "Message: {0}"},
+		},
+		missing: newMissingKeys(10),
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	translateFunc := funcMap["i18nTranslate"].(func(string, string, ...interface{}) (string, error))
+
+	translateFunc("nonexistent", "de")
+	translateFunc("nonexistent", "fr")
+	translateFunc("hello", "de")
+	translateFunc("msg", "de", "i18n:account")
+
+	entries, dropped := i18n.missing.report()
+	if dropped != 0 {
+		t.Errorf("expected 0 dropped, got %d", dropped)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d: %+v", len(entries), entries)
+	}
+
+	// Most frequent first: the missing key shares one entry across languages
+	if entries[0].Kind != missingKindKey || entries[0].Key != "nonexistent" || entries[0].Hits != 2 {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].Language != "" {
+		t.Errorf("expected no language for missing key, got %q", entries[0].Language)
+	}
+	if entries[1].Kind != missingKindLanguage || entries[1].Key != "account" || entries[1].Language != "de" {
+		t.Errorf("expected nested argument miss for account/de, got %+v", entries[1])
+	}
+	if entries[2].Kind != missingKindLanguage || entries[2].Key != "hello" || entries[2].Language != "de" {
+		t.Errorf("expected language miss for hello/de, got %+v", entries[2])
+	}
+	if entries[2].FirstSeen.IsZero() || entries[2].LastSeen.Before(entries[2].FirstSeen) {
+		t.Errorf("unexpected timestamps: %+v", entries[2])
+	}
+}
+
+func TestMissingKeysBounded(t *testing.T) {
+	m := newMissingKeys(2)
+	m.record(nil, missingKindKey, "a", "en")
+	m.record(nil, missingKindKey, "b", "en")
+	m.record(nil, missingKindKey, "c", "en")
+	m.record(nil, missingKindKey, "a", "en")
+
+	entries, dropped := m.report()
+	if len(entries) != 2 {
+		t.Errorf("expected 2 entries, got %d", len(entries))
+	}
+	if dropped != 1 {
+		t.Errorf("expected 1 dropped miss, got %d", dropped)
+	}
+	if entries[0].Key != "a" || entries[0].Hits != 2 {
+		t.Errorf("expected existing entry to keep counting, got %+v", entries[0])
+	}
+
+	m.reset()
+	entries, dropped = m.report()
+	if len(entries) != 0 || dropped != 0 {
+		t.Errorf("expected empty collector after reset, got %d entries, %d dropped", len(entries), dropped)
+	}
+}
+
+func TestMissingKeysNilCollector(t *testing.T) {
+	var m *missingKeys
+	m.record(nil, missingKindKey, "a", "en")
+	m.reset()
+
+	entries, _ := m.report()
+	if len(entries) != 0 {
+		t.Errorf("expected no entries for nil collector, got %d", len(entries))
+	}
+}
+
+func TestMissingKeysRecordsRequestPath(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{},
+		missing:      newMissingKeys(10),
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	translateFunc := funcMap["i18nTranslateCtx"].(func(*templates.TemplateContext, string, string, ...interface{}) (string, error))
+
+	for _, path := range []string{"/checkout", "/cart", "/checkout"} {
+		ctx := &templates.TemplateContext{Req: httptest.NewRequest(http.MethodGet, path, nil)}
+		result, err := translateFunc(ctx, "missing.key", "de")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "missing.key" {
+			t.Errorf("expected key as fallback, got %q", result)
+		}
+	}
+
+	entries, _ := i18n.missing.report()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if len(entries[0].Paths) != 2 || entries[0].Paths[0] != "/checkout" || entries[0].Paths[1] != "/cart" {
+		t.Errorf("expected distinct paths [/checkout /cart], got %v", entries[0].Paths)
+	}
+}
+
+func TestAdminMissingEndpoint(t *testing.T) {
+	dictFile := createTestDictFile(t, `{"hello": {"en": "Hello"}}`)
+
+	i18n := &I18n{DictFile: dictFile}
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	defer i18n.Cleanup()
+
+	i18n.translate(nil, "hello", "fr", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/i18n/missing?dict_file="+dictFile, nil)
+	rec := httptest.NewRecorder()
+	if err := (adminAPI{}).handleMissing(rec, req); err != nil {
+		t.Fatalf("handleMissing failed: %v", err)
+	}
+
+	var reports []MissingReport
+	if err := json.NewDecoder(rec.Body).Decode(&reports); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(reports) != 1 || len(reports[0].Entries) != 1 {
+		t.Fatalf("expected 1 report with 1 entry, got %+v", reports)
+	}
+	if reports[0].Entries[0].Key != "hello" || reports[0].Entries[0].Language != "fr" {
+		t.Errorf("unexpected entry: %+v", reports[0].Entries[0])
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/i18n/missing?dict_file="+dictFile, nil)
+	rec = httptest.NewRecorder()
+	if err := (adminAPI{}).handleMissing(rec, req); err != nil {
+		t.Fatalf("handleMissing DELETE failed: %v", err)
+	}
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204 for DELETE, got %d", rec.Code)
+	}
+	if entries, _ := i18n.missing.report(); len(entries) != 0 {
+		t.Errorf("expected collector to be cleared, got %d entries", len(entries))
+	}
+}
+
+func TestAdminMissingMergesInstances(t *testing.T) {
+	dictFile := createTestDictFile(t, `{"hello": {"en": "Hello"}}`)
+
+	var stubCaddyCtx caddy.Context
+	first := &I18n{DictFile: dictFile}
+	second := &I18n{DictFile: dictFile}
+	for _, i := range []*I18n{first, second} {
+		if err := i.Provision(stubCaddyCtx); err != nil {
+			t.Fatalf("Provision failed: %v", err)
+		}
+		defer i.Cleanup()
+	}
+
+	first.translate(httptest.NewRequest(http.MethodGet, "/a", nil), "hello", "fr", nil)
+	second.translate(httptest.NewRequest(http.MethodGet, "/b", nil), "hello", "fr", nil)
+	second.translate(httptest.NewRequest(http.MethodGet, "/a", nil), "hello", "fr", nil)
+	second.translate(nil, "unknown", "de", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/i18n/missing?dict_file="+dictFile, nil)
+	rec := httptest.NewRecorder()
+	if err := (adminAPI{}).handleMissing(rec, req); err != nil {
+		t.Fatalf("handleMissing failed: %v", err)
+	}
+
+	var reports []MissingReport
+	if err := json.NewDecoder(rec.Body).Decode(&reports); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(reports) != 1 || len(reports[0].Entries) != 2 {
+		t.Fatalf("expected 1 report with 2 entries, got %+v", reports)
+	}
+	entry := reports[0].Entries[0]
+	if entry.Key != "hello" || entry.Language != "fr" || entry.Hits != 3 {
+		t.Errorf("unexpected merged entry: %+v", entry)
+	}
+	if !slices.Equal(entry.Paths, []string{"/a", "/b"}) {
+		t.Errorf("expected merged paths [/a /b], got %v", entry.Paths)
+	}
+	if reports[0].Entries[1].Key != "unknown" {
+		t.Errorf("unexpected second entry: %+v", reports[0].Entries[1])
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/i18n/missing?dict_file="+dictFile, nil)
+	rec = httptest.NewRecorder()
+	if err := (adminAPI{}).handleMissing(rec, req); err != nil {
+		t.Fatalf("handleMissing DELETE failed: %v", err)
+	}
+	for _, i := range []*I18n{first, second} {
+		if entries, _ := i.missing.report(); len(entries) != 0 {
+			t.Errorf("expected every collector to be cleared, got %d entries", len(entries))
+		}
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"golang.org/x/text/language"
+)
+
+func init() {
+	caddy.RegisterModule(Negotiate{})
+}
+
+// Sources of the negotiated language, as used in Negotiate.Order.
+const (
+	negotiateSourcePath    = "path"
+	negotiateSourceQuery   = "query"
+	negotiateSourceCookie  = "cookie"
+	negotiateSourceHeader  = "header"
+	negotiateSourceDefault = "default"
+)
+
+// defaultNegotiateOrder is the order in which the sources are consulted if
+// Negotiate.Order is not configured.
+var defaultNegotiateOrder = []string{
+	negotiateSourcePath,
+	negotiateSourceQuery,
+	negotiateSourceCookie,
+	negotiateSourceHeader,
+}
+
+// defaultPersistMaxAge is the lifetime of the language cookie if
+// Negotiate.PersistMaxAge is not configured.
+const defaultPersistMaxAge = 365 * 24 * time.Hour
+
+// Negotiate is an HTTP middleware that determines the language of a request
+// once, so that templates don't have to. The sources are consulted in the
+// configured order:
+//   - path: the first path segment, e.g. /de/about
+//   - query: a query parameter, e.g. ?lang=de
+//   - cookie: a cookie, e.g. lang=de
+//   - header: the Accept-Language header, honoring q-values
+//
+// Only languages found in the dictionary are accepted; values are matched
+// case-insensitively, and Accept-Language entries like "de-AT" also match
+// "de". If no source yields a supported language, Default is used.
+//
+// The result is stored in the placeholder {http.i18n.lang}, which i18nT
+// consults by default, and the source it came from in {http.i18n.lang_source}.
+//
+// Since the result may depend on the Cookie and Accept-Language request
+// headers, they are added to the Vary response header when consulted, so that
+// shared caches don't serve one language to users of another.
+//
+// For sites with locale-prefixed URLs such as /de/about, StripPrefix removes
+// the language prefix before the request reaches later handlers, and Redirect
+// sends requests without a prefix to the negotiated locale. The prefix is
+// available as {http.i18n.prefix}, e.g. "/de", or "" without one.
+type Negotiate struct {
+	// DictFile is the path to the translations dictionary file in JSON format.
+	// The languages it contains are the supported languages.
+	DictFile string `json:"dict_file,omitempty"`
+
+	// Order lists the sources to consult: path, query, cookie and header.
+	// Defaults to all of them in this order.
+	Order []string `json:"order,omitempty"`
+
+	// QueryParam is the name of the query parameter. Defaults to "lang".
+	QueryParam string `json:"query_param,omitempty"`
+
+	// Cookie is the name of the cookie that is read and, if Persist is
+	// enabled, written. Defaults to "lang".
+	Cookie string `json:"cookie,omitempty"`
+
+	// Default is the language used if no source yields a supported
+	// language. Defaults to "en".
+	Default string `json:"default,omitempty"`
+
+	// Persist stores a language chosen by path or query parameter in the
+	// cookie, so that it sticks on later requests without them.
+	Persist bool `json:"persist,omitempty"`
+
+	// PersistMaxAge is the lifetime of the persisted cookie. Defaults to
+	// one year.
+	PersistMaxAge caddy.Duration `json:"persist_max_age,omitempty"`
+
+	// StripPrefix removes a language prefix from the request path, so that
+	// /de/about is served from /about. Requires the path source.
+	StripPrefix bool `json:"strip_prefix,omitempty"`
+
+	// Redirect answers GET and HEAD requests without a language prefix with
+	// a 302 redirect to the same path prefixed by the negotiated language.
+	// Requires the path source.
+	Redirect bool `json:"redirect,omitempty"`
+
+	// dict holds the loaded dictionary.
+	dict *I18n
+}
+
+// CaddyModule returns the Caddy module information for registration.
+func (Negotiate) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "http.handlers.i18n_negotiate",
+		New: func() caddy.Module { return new(Negotiate) },
+	}
+}
+
+// Provision validates the configuration, applies defaults and loads the
+// dictionary.
+func (n *Negotiate) Provision(ctx caddy.Context) error {
+	if n.DictFile == "" {
+		return errors.New("i18n_negotiate: dict_file is required")
+	}
+	for _, source := range n.Order {
+		switch source {
+		case negotiateSourcePath, negotiateSourceQuery, negotiateSourceCookie, negotiateSourceHeader:
+		default:
+			return fmt.Errorf("i18n_negotiate: unknown source %q; must be path, query, cookie or header", source)
+		}
+	}
+	if len(n.Order) == 0 {
+		n.Order = defaultNegotiateOrder
+	}
+	if (n.StripPrefix || n.Redirect) && !slices.Contains(n.Order, negotiateSourcePath) {
+		return errors.New("i18n_negotiate: strip_prefix and redirect require the path source")
+	}
+	if n.QueryParam == "" {
+		n.QueryParam = "lang"
+	}
+	if n.Cookie == "" {
+		n.Cookie = "lang"
+	}
+	if n.Default == "" {
+		n.Default = "en"
+	}
+	if n.PersistMaxAge <= 0 {
+		n.PersistMaxAge = caddy.Duration(defaultPersistMaxAge)
+	}
+
+	dict, err := newDictionary(ctx, n.DictFile)
+	if err != nil {
+		return err
+	}
+	n.dict = dict
+	internalDictionaries.add(dict)
+	return nil
+}
+
+// Cleanup releases the loaded dictionary.
+func (n *Negotiate) Cleanup() error {
+	if n.dict != nil {
+		internalDictionaries.remove(n.dict)
+	}
+	return nil
+}
+
+// ServeHTTP implements caddyhttp.MiddlewareHandler.
+func (n *Negotiate) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	lang, source := n.negotiate(r)
+	n.addVary(w.Header(), source)
+
+	// The supportedLanguage check prevents redirect loops if Default is not
+	// in the dictionary.
+	if n.Redirect && source != negotiateSourcePath &&
+		(r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+		n.dict.supportedLanguage(lang) != "" {
+		target := *r.URL
+		target.Path = "/" + lang + r.URL.Path
+		target.RawPath = ""
+		http.Redirect(w, r, target.RequestURI(), http.StatusFound)
+		return nil
+	}
+
+	prefix := ""
+	if source == negotiateSourcePath {
+		prefix = "/" + lang
+		if n.StripPrefix {
+			stripPathPrefix(r.URL, "/"+pathLang(r.URL.Path))
+		}
+	}
+
+	if repl, ok := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer); ok {
+		repl.Set("http.i18n.lang", lang)
+		repl.Set("http.i18n.lang_source", source)
+		repl.Set("http.i18n.prefix", prefix)
+	}
+
+	if n.Persist && (source == negotiateSourcePath || source == negotiateSourceQuery) {
+		if c, err := r.Cookie(n.Cookie); err != nil || c.Value != lang {
+			http.SetCookie(w, &http.Cookie{
+				Name:     n.Cookie,
+				Value:    lang,
+				Path:     "/",
+				MaxAge:   int(time.Duration(n.PersistMaxAge).Seconds()),
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+	}
+
+	return next.ServeHTTP(w, r)
+}
+
+// negotiate returns the language of r and the source it was taken from.
+func (n *Negotiate) negotiate(r *http.Request) (string, string) {
+	for _, source := range n.Order {
+		var lang string
+		switch source {
+		case negotiateSourcePath:
+			lang = n.dict.supportedLanguage(pathLang(r.URL.Path))
+		case negotiateSourceQuery:
+			lang = n.dict.supportedLanguage(r.URL.Query().Get(n.QueryParam))
+		case negotiateSourceCookie:
+			if c, err := r.Cookie(n.Cookie); err == nil {
+				lang = n.dict.supportedLanguage(c.Value)
+			}
+		case negotiateSourceHeader:
+			lang = n.dict.acceptedLanguage(r.Header.Get("Accept-Language"))
+		}
+		if lang != "" {
+			return lang, source
+		}
+	}
+	return n.Default, negotiateSourceDefault
+}
+
+// addVary adds the request headers consulted to negotiate the language from
+// source to the Vary header h.
+func (n *Negotiate) addVary(h http.Header, source string) {
+	for _, consulted := range n.Order {
+		switch consulted {
+		case negotiateSourceCookie:
+			addVaryHeader(h, "Cookie")
+		case negotiateSourceHeader:
+			addVaryHeader(h, "Accept-Language")
+		}
+		if consulted == source {
+			return
+		}
+	}
+}
+
+// addVaryHeader adds field to the Vary header h unless it is already listed.
+func addVaryHeader(h http.Header, field string) {
+	for _, value := range h.Values("Vary") {
+		for _, existing := range strings.Split(value, ",") {
+			existing = strings.TrimSpace(existing)
+			if existing == "*" || strings.EqualFold(existing, field) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", field)
+}
+
+// pathLang returns the first segment of an URL path, e.g. "de" for "/de/about".
+func pathLang(urlPath string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(urlPath, "/"), "/")
+	return segment
+}
+
+// stripPathPrefix removes prefix from the path of u, leaving at least "/".
+func stripPathPrefix(u *url.URL, prefix string) {
+	u.Path = strings.TrimPrefix(u.Path, prefix)
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	if u.RawPath != "" {
+		u.RawPath = strings.TrimPrefix(u.RawPath, prefix)
+		if u.RawPath == "" {
+			u.RawPath = "/"
+		}
+	}
+}
+
+// supportedLanguage returns the spelling used in the dictionary of lang, which
+// is matched case-insensitively, or "" if the dictionary doesn't contain it.
+func (i *I18n) supportedLanguage(lang string) string {
+	if lang == "" {
+		return ""
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if _, ok := i.langCounts[lang]; ok {
+		return lang
+	}
+	for candidate := range i.langCounts {
+		if strings.EqualFold(candidate, lang) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// acceptedLanguage returns the supported language preferred by an
+// Accept-Language header, or "" if none of them is supported. Each entry is
+// tried as is and then by its base language, so "de-AT" matches "de".
+func (i *I18n) acceptedLanguage(header string) string {
+	if header == "" {
+		return ""
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return ""
+	}
+	for _, tag := range tags {
+		if lang := i.supportedLanguage(tag.String()); lang != "" {
+			return lang
+		}
+		if base, conf := tag.Base(); conf != language.No {
+			if lang := i.supportedLanguage(base.String()); lang != "" {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// Interface guards ensure that Negotiate implements the required interfaces.
+var (
+	_ caddy.Provisioner           = (*Negotiate)(nil)
+	_ caddy.CleanerUpper          = (*Negotiate)(nil)
+	_ caddyhttp.MiddlewareHandler = (*Negotiate)(nil)
+)
@@ -0,0 +1,315 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+)
+
+func newTestNegotiate(t *testing.T, n *Negotiate) *Negotiate {
+	t.Helper()
+	n.DictFile = createTestDictFile(t, `{
+		"hello": {"de": "Hallo", "en": "Hello", "pt-BR": "Olá"},
+		"bye": {"fr": "Au revoir"}
+	}`)
+	var stubCaddyCtx caddy.Context
+	if err := n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	t.Cleanup(func() { n.Cleanup() })
+	return n
+}
+
+func TestNegotiateDoesNotRegisterInstance(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{})
+
+	if got := instances.forDictFile(n.DictFile); len(got) != 0 {
+		t.Errorf("expected no registered instances for the negotiate dictionary, got %d", len(got))
+	}
+}
+
+func TestNegotiateSources(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{})
+
+	tests := []struct {
+		name           string
+		target         string
+		cookie         string
+		acceptLanguage string
+		expectedLang   string
+		expectedSource string
+	}{
+		{"path prefix", "/de/about?lang=fr", "en", "fr", "de", "path"},
+		{"unsupported path prefix", "/about?lang=fr", "", "", "fr", "query"},
+		{"case-insensitive query", "/?lang=PT-br", "", "", "pt-BR", "query"},
+		{"cookie", "/", "fr", "de", "fr", "cookie"},
+		{"unsupported cookie", "/", "it", "de", "de", "header"},
+		{"accept-language q-values", "/", "", "it;q=1, fr;q=0.5, de;q=0.8", "de", "header"},
+		{"accept-language base language", "/", "", "de-AT, en;q=0.5", "de", "header"},
+		{"default", "/", "", "it, es", "en", "default"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+		}
+		if tt.acceptLanguage != "" {
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+		}
+
+		lang, source := n.negotiate(req)
+		if lang != tt.expectedLang || source != tt.expectedSource {
+			t.Errorf("%s: expected %q from %s, got %q from %s", tt.name, tt.expectedLang, tt.expectedSource, lang, source)
+		}
+	}
+}
+
+func TestNegotiateCustomOrder(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{Order: []string{"header", "query"}, QueryParam: "hl", Default: "de"})
+
+	req := httptest.NewRequest(http.MethodGet, "/fr/?hl=fr", nil)
+	req.Header.Set("Accept-Language", "en")
+	if lang, source := n.negotiate(req); lang != "en" || source != "header" {
+		t.Errorf("expected 'en' from header, got %q from %s", lang, source)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/fr/", nil)
+	if lang, source := n.negotiate(req); lang != "de" || source != "default" {
+		t.Errorf("expected default 'de', got %q from %s", lang, source)
+	}
+}
+
+func TestNegotiateInvalidOrder(t *testing.T) {
+	n := &Negotiate{DictFile: createTestDictFile(t, `{}`), Order: []string{"session"}}
+	var stubCaddyCtx caddy.Context
+	if err := n.Provision(stubCaddyCtx); err == nil {
+		t.Error("expected error for unknown source, got nil")
+	}
+}
+
+func TestNegotiateSetsPlaceholdersAndCookie(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{Persist: true})
+
+	req, repl := newTestRequest("/?lang=de", nil)
+	rec := httptest.NewRecorder()
+	if err := n.ServeHTTP(rec, req, nextHandler); err != errNextCalled {
+		t.Fatalf("expected next handler to be called, got %v", err)
+	}
+
+	if lang, _ := repl.GetString("http.i18n.lang"); lang != "de" {
+		t.Errorf("expected placeholder 'de', got %q", lang)
+	}
+	if source, _ := repl.GetString("http.i18n.lang_source"); source != "query" {
+		t.Errorf("expected source 'query', got %q", source)
+	}
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "lang" || cookies[0].Value != "de" {
+		t.Fatalf("expected cookie lang=de, got %v", cookies)
+	}
+	if cookies[0].MaxAge != 365*24*60*60 {
+		t.Errorf("expected MaxAge of one year, got %d", cookies[0].MaxAge)
+	}
+
+	// The cookie is not written again if it already holds the language.
+	req, _ = newTestRequest("/?lang=de", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "de"})
+	rec = httptest.NewRecorder()
+	n.ServeHTTP(rec, req, nextHandler)
+	if cookies := rec.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("expected no cookie, got %v", cookies)
+	}
+
+	// Languages from Accept-Language are not persisted.
+	req, _ = newTestRequest("/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	rec = httptest.NewRecorder()
+	n.ServeHTTP(rec, req, nextHandler)
+	if cookies := rec.Result().Cookies(); len(cookies) != 0 {
+		t.Errorf("expected no cookie, got %v", cookies)
+	}
+}
+
+func TestI18nTUsesNegotiatedLanguage(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{})
+
+	req, _ := newTestRequest("/de/", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req, nextHandler)
+
+	i18n := &I18n{DictFile: n.DictFile}
+	var stubCaddyCtx caddy.Context
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	t.Cleanup(func() { i18n.Cleanup() })
+
+	if lang := i18n.requestLang(req); lang != "de" {
+		t.Errorf("expected 'de', got %q", lang)
+	}
+}
+
+func TestNegotiateStripPrefix(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{StripPrefix: true})
+
+	tests := []struct {
+		target         string
+		expectedPath   string
+		expectedPrefix string
+	}{
+		{"/de/about?x=1", "/about", "/de"},
+		{"/PT-br/", "/", "/pt-BR"},
+		{"/fr", "/", "/fr"},
+		{"/about", "/about", ""},
+	}
+
+	for _, tt := range tests {
+		req, repl := newTestRequest(tt.target, nil)
+		if err := n.ServeHTTP(httptest.NewRecorder(), req, nextHandler); err != errNextCalled {
+			t.Fatalf("%s: expected next handler to be called, got %v", tt.target, err)
+		}
+		if req.URL.Path != tt.expectedPath {
+			t.Errorf("%s: expected path %q, got %q", tt.target, tt.expectedPath, req.URL.Path)
+		}
+		if prefix, _ := repl.GetString("http.i18n.prefix"); prefix != tt.expectedPrefix {
+			t.Errorf("%s: expected prefix %q, got %q", tt.target, tt.expectedPrefix, prefix)
+		}
+	}
+}
+
+func TestNegotiateRedirect(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{Redirect: true})
+
+	req, _ := newTestRequest("/about?x=1", nil)
+	req.Header.Set("Accept-Language", "fr-CH, de;q=0.5")
+	rec := httptest.NewRecorder()
+	if err := n.ServeHTTP(rec, req, nextHandler); err != nil {
+		t.Fatalf("ServeHTTP failed: %v", err)
+	}
+	if rec.Code != http.StatusFound {
+		t.Fatalf("expected status 302, got %d", rec.Code)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/fr/about?x=1" {
+		t.Errorf("expected Location '/fr/about?x=1', got %q", loc)
+	}
+
+	// Prefixed requests and other methods are passed on.
+	req, _ = newTestRequest("/de/about", nil)
+	if err := n.ServeHTTP(httptest.NewRecorder(), req, nextHandler); err != errNextCalled {
+		t.Errorf("expected next handler for prefixed path, got %v", err)
+	}
+	req, _ = newTestRequest("/about", nil)
+	req.Method = http.MethodPost
+	if err := n.ServeHTTP(httptest.NewRecorder(), req, nextHandler); err != errNextCalled {
+		t.Errorf("expected next handler for POST, got %v", err)
+	}
+}
+
+func TestNegotiateRedirectUnsupportedDefault(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{Redirect: true, Default: "it"})
+
+	req, _ := newTestRequest("/about", nil)
+	if err := n.ServeHTTP(httptest.NewRecorder(), req, nextHandler); err != errNextCalled {
+		t.Errorf("expected next handler instead of redirect loop, got %v", err)
+	}
+}
+
+func TestNegotiatePrefixRequiresPathSource(t *testing.T) {
+	n := &Negotiate{DictFile: createTestDictFile(t, `{}`), Order: []string{"query"}, StripPrefix: true}
+	var stubCaddyCtx caddy.Context
+	if err := n.Provision(stubCaddyCtx); err == nil {
+		t.Error("expected error for strip_prefix without path source, got nil")
+	}
+}
+
+func TestNegotiateFollowsDictionaryReload(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{})
+
+	req := httptest.NewRequest(http.MethodGet, "/it/", nil)
+	if lang, _ := n.negotiate(req); lang != "en" {
+		t.Fatalf("expected 'en' before reload, got %q", lang)
+	}
+
+	e := newTestEditor(t, n.DictFile)
+	edit := httptest.NewRequest(http.MethodPost, "/_i18n/translations", strings.NewReader(`{"key": "hello", "lang": "it", "value": "Ciao"}`))
+	edit.Header.Set("Authorization", "Bearer secret")
+	if err := e.ServeHTTP(httptest.NewRecorder(), edit, nextHandler); err != nil {
+		t.Fatalf("editor POST failed: %v", err)
+	}
+	if lang, _ := n.negotiate(req); lang != "it" {
+		t.Errorf("expected 'it' after reload, got %q", lang)
+	}
+}
+
+func TestNegotiateVary(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{})
+
+	tests := []struct {
+		name     string
+		target   string
+		cookie   string
+		expected []string
+	}{
+		{"path", "/de/", "", nil},
+		{"query", "/?lang=de", "", nil},
+		{"cookie", "/", "fr", []string{"Cookie"}},
+		{"header", "/", "", []string{"Cookie", "Accept-Language"}},
+	}
+
+	for _, tt := range tests {
+		req, _ := newTestRequest(tt.target, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+		}
+		req.Header.Set("Accept-Language", "de")
+		rec := httptest.NewRecorder()
+		n.ServeHTTP(rec, req, nextHandler)
+
+		if vary := rec.Header().Values("Vary"); !slices.Equal(vary, tt.expected) {
+			t.Errorf("%s: expected Vary %v, got %v", tt.name, tt.expected, vary)
+		}
+	}
+}
+
+func TestAddVaryHeaderSkipsListedFields(t *testing.T) {
+	h := make(http.Header)
+	h.Add("Vary", "Accept-Encoding, cookie")
+
+	addVaryHeader(h, "Cookie")
+	addVaryHeader(h, "Accept-Language")
+	addVaryHeader(h, "Accept-Language")
+
+	expected := []string{"Accept-Encoding, cookie", "Accept-Language"}
+	if vary := h.Values("Vary"); !slices.Equal(vary, expected) {
+		t.Errorf("expected Vary %v, got %v", expected, vary)
+	}
+}
+
+func TestNegotiateRedirectVary(t *testing.T) {
+	n := newTestNegotiate(t, &Negotiate{Order: []string{"path", "header"}, Redirect: true})
+
+	req, _ := newTestRequest("/about", nil)
+	rec := httptest.NewRecorder()
+	n.ServeHTTP(rec, req, nextHandler)
+	if vary := rec.Header().Get("Vary"); vary != "Accept-Language" {
+		t.Errorf("expected Vary 'Accept-Language' on redirect, got %q", vary)
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Rounding modes for number formatting. CLDR rounds half-even by default.
+const (
+	roundHalfEven = "half-even"
+	roundHalfUp   = "half-up"
+	roundUp       = "up"
+	roundDown     = "down"
+	roundCeiling  = "ceiling"
+	roundFloor    = "floor"
+)
+
+// numberOptions controls how a number is formatted.
+type numberOptions struct {
+	// minFraction and maxFraction are the minimum and maximum number of
+	// fraction digits.
+	minFraction, maxFraction int
+
+	// rounding is one of the rounding modes.
+	rounding string
+
+	// grouping enables the grouping separator, e.g. "1,234".
+	grouping bool
+}
+
+// defaultNumberOptions follow the CLDR decimal pattern #,##0.###.
+var defaultNumberOptions = numberOptions{maxFraction: 3, rounding: roundHalfEven, grouping: true}
+
+// parseNumberOptions applies space-separated key=value options to defaults.
+// Supported keys are min and max (fraction digits), round (rounding mode) and
+// grouping (true or false), e.g. "min=2 max=2 round=half-up".
+func parseNumberOptions(opts string, defaults numberOptions) (numberOptions, error) {
+	result := defaults
+	for _, opt := range strings.Fields(opts) {
+		key, val, ok := strings.Cut(opt, "=")
+		if !ok {
+			return result, fmt.Errorf("invalid number option %q: expected key=value", opt)
+		}
+		switch key {
+		case "min", "max":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 || n > 20 {
+				return result, fmt.Errorf("invalid number option %q: must be between 0 and 20", opt)
+			}
+			if key == "min" {
+				result.minFraction = n
+				result.maxFraction = max(result.maxFraction, n)
+			} else {
+				result.maxFraction = n
+				result.minFraction = min(result.minFraction, n)
+			}
+		case "round":
+			switch val {
+			case roundHalfEven, roundHalfUp, roundUp, roundDown, roundCeiling, roundFloor:
+				result.rounding = val
+			default:
+				return result, fmt.Errorf("invalid number option %q: unknown rounding mode", opt)
+			}
+		case "grouping":
+			grouping, err := strconv.ParseBool(val)
+			if err != nil {
+				return result, fmt.Errorf("invalid number option %q: %v", opt, err)
+			}
+			result.grouping = grouping
+		default:
+			return result, fmt.Errorf("unknown number option %q", key)
+		}
+	}
+	return result, nil
+}
+
+// maxDecimalExponent and maxDecimalDigits bound the values toDecimal
+// accepts. Formatting cost grows with the exponent, so a value like
+// "1e10000000" from a query parameter would otherwise take seconds of CPU.
+const (
+	maxDecimalExponent = 40
+	maxDecimalDigits   = 40
+
+	// maxDecimalLength bounds the length of strings before they are parsed.
+	maxDecimalLength = 100
+)
+
+// toDecimal converts a template value to a decimal. Strings are parsed
+// exactly, so "500.995" is not subject to floating-point errors. Values with
+// an exponent or digit count beyond maxDecimalExponent and maxDecimalDigits
+// are rejected.
+func toDecimal(value interface{}) (decimal.Decimal, error) {
+	d, err := parseDecimal(value)
+	if err != nil {
+		return d, err
+	}
+	if exp := d.Exponent(); exp > maxDecimalExponent || exp < -maxDecimalExponent || d.NumDigits() > maxDecimalDigits {
+		return decimal.Decimal{}, fmt.Errorf("cannot format %v as a number: out of range", value)
+	}
+	return d, nil
+}
+
+// parseDecimal converts a template value to a decimal without range checks.
+func parseDecimal(value interface{}) (decimal.Decimal, error) {
+	switch v := value.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case int:
+		return decimal.NewFromInt(int64(v)), nil
+	case int8:
+		return decimal.NewFromInt(int64(v)), nil
+	case int16:
+		return decimal.NewFromInt(int64(v)), nil
+	case int32:
+		return decimal.NewFromInt(int64(v)), nil
+	case int64:
+		return decimal.NewFromInt(v), nil
+	case uint:
+		return decimal.NewFromUint64(uint64(v)), nil
+	case uint8:
+		return decimal.NewFromUint64(uint64(v)), nil
+	case uint16:
+		return decimal.NewFromUint64(uint64(v)), nil
+	case uint32:
+		return decimal.NewFromUint64(uint64(v)), nil
+	case uint64:
+		return decimal.NewFromUint64(v), nil
+	case float32:
+		return parseDecimal(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return decimal.Decimal{}, fmt.Errorf("cannot format %v as a number", v)
+		}
+		return decimal.NewFromFloat(v), nil
+	case json.Number:
+		if len(v) > maxDecimalLength {
+			return decimal.Decimal{}, fmt.Errorf("cannot format a %d-character number", len(v))
+		}
+		return decimal.NewFromString(string(v))
+	case string:
+		v = strings.TrimSpace(v)
+		if len(v) > maxDecimalLength {
+			return decimal.Decimal{}, fmt.Errorf("cannot format a %d-character number", len(v))
+		}
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			return d, fmt.Errorf("cannot format %q as a number", v)
+		}
+		return d, nil
+	}
+	return decimal.Decimal{}, fmt.Errorf("cannot format %T as a number", value)
+}
+
+// roundDecimal rounds d to places fraction digits using mode.
+func roundDecimal(d decimal.Decimal, places int, mode string) decimal.Decimal {
+	p := int32(places)
+	switch mode {
+	case roundHalfUp:
+		return d.Round(p)
+	case roundUp:
+		return d.RoundUp(p)
+	case roundDown:
+		return d.RoundDown(p)
+	case roundCeiling:
+		return d.RoundCeil(p)
+	case roundFloor:
+		return d.RoundFloor(p)
+	}
+	return d.RoundBank(p)
+}
+
+// numberSymbols holds the number formatting data of a locale.
+type numberSymbols struct {
+	// digits are the digits of the locale's numbering system, e.g. "٠" to "٩".
+	digits [10]string
+
+	// decimal and group are the decimal and grouping separators.
+	decimal, group string
+
+	// primaryGroup and secondaryGroup are the grouping sizes, e.g. 3 and 2
+	// for "12,34,567" in Hindi.
+	primaryGroup, secondaryGroup int
+
+	// minGrouping is the CLDR minimumGroupingDigits: the integer part is
+	// only grouped if it has at least minGrouping digits more than
+	// primaryGroup, e.g. 2 for "1234" but "12.345" in Spanish.
+	minGrouping int
+
+	// minusPrefix and minusSuffix surround negative numbers.
+	minusPrefix, minusSuffix string
+
+	// percentPrefix and percentSuffix surround percentages, e.g. "" and " %".
+	percentPrefix, percentSuffix string
+}
+
+// maxCachedSymbols is the number of locales whose numberSymbols are cached.
+// Languages may come from requests, so further locales are derived on every
+// call instead of growing the cache without bound.
+const maxCachedSymbols = 512
+
+// localeSymbols caches the numberSymbols of each locale, keyed by language.Tag.
+var localeSymbols = struct {
+	mu      sync.RWMutex
+	symbols map[language.Tag]*numberSymbols
+}{symbols: make(map[language.Tag]*numberSymbols)}
+
+// minimumGroupingDigits lists the locales whose CLDR minimumGroupingDigits is
+// not 1. golang.org/x/text doesn't apply it, so it is kept here. Locales
+// inherit the value of their parent, see minGroupingFor.
+var minimumGroupingDigits = map[string]int{
+	"bg":     2,
+	"es":     2,
+	"es-419": 1,
+	"et":     2,
+	"lv":     2,
+	"pl":     2,
+	"pt-PT":  2,
+}
+
+// minGroupingFor returns the CLDR minimumGroupingDigits of tag, following
+// the CLDR parent locales, e.g. es-AR to es-419 and pt-AO to pt-PT.
+func minGroupingFor(tag language.Tag) int {
+	for t := tag; ; t = t.Parent() {
+		if n, ok := minimumGroupingDigits[t.String()]; ok {
+			return n
+		}
+		if t.IsRoot() {
+			return 1
+		}
+	}
+}
+
+// symbolsFor returns the number symbols of lang. golang.org/x/text contains
+// the CLDR number data but doesn't export it, so the symbols are derived once
+// per locale from numbers formatted by x/text.
+func symbolsFor(lang string) *numberSymbols {
+	tag := language.Make(lang)
+	localeSymbols.mu.RLock()
+	sym, ok := localeSymbols.symbols[tag]
+	localeSymbols.mu.RUnlock()
+	if ok {
+		return sym
+	}
+
+	sym = newNumberSymbols(tag)
+
+	localeSymbols.mu.Lock()
+	defer localeSymbols.mu.Unlock()
+	if len(localeSymbols.symbols) < maxCachedSymbols {
+		localeSymbols.symbols[tag] = sym
+	}
+	return sym
+}
+
+// newNumberSymbols derives the number symbols of tag.
+func newNumberSymbols(tag language.Tag) *numberSymbols {
+	p := message.NewPrinter(tag)
+	sym := &numberSymbols{minGrouping: minGroupingFor(tag)}
+	for d := range sym.digits {
+		sym.digits[d] = p.Sprint(number.Decimal(d))
+	}
+
+	// 1234567.5 shows the separators and the grouping sizes
+	var runs []int
+	var seps []string
+	var sep strings.Builder
+	s := p.Sprint(number.Decimal(1234567.5, number.MinFractionDigits(1), number.MaxFractionDigits(1)))
+	for len(s) > 0 {
+		if n := sym.digitLen(s); n > 0 {
+			if sep.Len() > 0 || len(runs) == 0 {
+				seps = append(seps, sep.String())
+				sep.Reset()
+				runs = append(runs, 0)
+			}
+			runs[len(runs)-1]++
+			s = s[n:]
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s)
+		sep.WriteString(s[:size])
+		s = s[size:]
+	}
+	if len(runs) >= 2 {
+		sym.decimal = seps[len(seps)-1]
+		intRuns := runs[:len(runs)-1]
+		if len(intRuns) >= 2 {
+			sym.group = seps[1]
+			sym.primaryGroup = intRuns[len(intRuns)-1]
+			sym.secondaryGroup = intRuns[len(intRuns)-2]
+		}
+	} else {
+		sym.decimal = "."
+	}
+
+	sym.minusPrefix, sym.minusSuffix, _ = strings.Cut(p.Sprint(number.Decimal(-1)), sym.digits[1])
+	sym.percentPrefix, sym.percentSuffix, _ = strings.Cut(p.Sprint(number.Percent(0.12)), sym.digits[1]+sym.digits[2])
+
+	return sym
+}
+
+// digitLen returns the length in bytes of the native digit at the start of
+// s, or 0 if s doesn't start with a digit.
+func (sym *numberSymbols) digitLen(s string) int {
+	for _, digit := range sym.digits {
+		if digit != "" && strings.HasPrefix(s, digit) {
+			return len(digit)
+		}
+	}
+	return 0
+}
+
+// formatDecimal formats d with the symbols of the locale: it is rounded to
+// opts.maxFraction digits, trailing zeros beyond opts.minFraction are removed,
+// and the integer part is grouped. The sign is not included, see sign.
+func (sym *numberSymbols) formatDecimal(d decimal.Decimal, opts numberOptions) string {
+	intPart, fracPart := plainDecimal(d, opts)
+
+	grouping := opts.grouping && sym.group != "" && len(intPart) >= sym.primaryGroup+sym.minGrouping
+
+	var sb strings.Builder
+	for idx, c := range intPart {
+		if idx > 0 && grouping && sym.isGroupBoundary(len(intPart)-idx) {
+			sb.WriteString(sym.group)
+		}
+		sb.WriteString(sym.digits[c-'0'])
+	}
+	if fracPart != "" {
+		sb.WriteString(sym.decimal)
+		for _, c := range fracPart {
+			sb.WriteString(sym.digits[c-'0'])
+		}
+	}
+	return sb.String()
+}
+
+// plainDecimal returns the ASCII digits of the absolute value of d before and
+// after the decimal point, rounded to opts.maxFraction digits and without
+// trailing zeros beyond opts.minFraction.
+func plainDecimal(d decimal.Decimal, opts numberOptions) (intPart, fracPart string) {
+	d = roundDecimal(d, opts.maxFraction, opts.rounding)
+	intPart, fracPart, _ = strings.Cut(d.Abs().StringFixed(int32(opts.maxFraction)), ".")
+	for len(fracPart) > opts.minFraction && strings.HasSuffix(fracPart, "0") {
+		fracPart = fracPart[:len(fracPart)-1]
+	}
+	return intPart, fracPart
+}
+
+// isGroupBoundary reports whether a grouping separator precedes the digit
+// with remaining digits to its right, including itself.
+func (sym *numberSymbols) isGroupBoundary(remaining int) bool {
+	if remaining == sym.primaryGroup {
+		return true
+	}
+	return remaining > sym.primaryGroup && sym.secondaryGroup > 0 &&
+		(remaining-sym.primaryGroup)%sym.secondaryGroup == 0
+}
+
+// sign adds the minus sign of the locale to formatted if d is negative after
+// rounding to opts, so that -0.001 doesn't become "-0".
+func (sym *numberSymbols) sign(d decimal.Decimal, opts numberOptions, formatted string) string {
+	if roundDecimal(d, opts.maxFraction, opts.rounding).IsNegative() {
+		return sym.minusPrefix + formatted + sym.minusSuffix
+	}
+	return formatted
+}
+
+// formatNumber formats value in lang with the given space-separated options.
+func formatNumber(value interface{}, lang, opts string) (string, error) {
+	d, err := toDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	options, err := parseNumberOptions(opts, defaultNumberOptions)
+	if err != nil {
+		return "", err
+	}
+	sym := symbolsFor(lang)
+	return sym.sign(d, options, sym.formatDecimal(d, options)), nil
+}
+
+// formatPercent formats the ratio value as a percentage in lang, e.g. 0.12 as
+// "12 %" in German, with the given space-separated number options. It shows
+// no fraction digits by default.
+func formatPercent(value interface{}, lang, opts string) (string, error) {
+	d, err := toDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	options, err := parseNumberOptions(opts, numberOptions{rounding: roundHalfEven, grouping: true})
+	if err != nil {
+		return "", err
+	}
+	d = d.Shift(2)
+	sym := symbolsFor(lang)
+	return sym.sign(d, options, sym.percentPrefix+sym.formatDecimal(d, options)+sym.percentSuffix), nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		lang     string
+		opts     string
+		expected string
+	}{
+		{500.99, "de", "", "500,99"},
+		{500.99, "en", "", "500.99"},
+		{1234567.891, "en", "", "1,234,567.891"},
+		{1234567.891, "de", "", "1.234.567,891"},
+		{"1234567.5", "fr", "min=2", "1\u00a0234\u00a0567,50"},
+		{1234567, "hi", "", "12,34,567"},
+		{1234567, "de-CH", "", "1’234’567"},
+		{-1234.5, "ar", "", "\u061c-١٬٢٣٤٫٥"},
+		{1234.5678, "en", "max=2", "1,234.57"},
+		{1234.5, "en", "max=0", "1,234"},
+		{1235.5, "en", "max=0", "1,236"},
+		{1234.5, "en", "max=0 round=half-up", "1,235"},
+		{"500.995", "en", "max=2 round=half-up", "501"},
+		{"500.995", "en", "min=2 max=2 round=half-up", "501.00"},
+		{1.21, "en", "max=1 round=up", "1.3"},
+		{-1.29, "en", "max=1 round=down", "-1.2"},
+		{-1.21, "en", "max=1 round=floor", "-1.3"},
+		{-1.29, "en", "max=1 round=ceiling", "-1.2"},
+		{12345, "en", "grouping=false", "12345"},
+		{1234, "es", "", "1234"},
+		{12345, "es", "", "12.345"},
+		{1234.5, "pl", "", "1234,5"},
+		{12345, "pl", "", "12\u00a0345"},
+		{1234, "pt-PT", "", "1234"},
+		{1234, "pt-AO", "", "1234"},
+		{1234, "pt", "", "1.234"},
+		{1234, "es-MX", "", "1,234"},
+		{5, "de", "min=2", "5,00"},
+		{-0.0001, "en", "", "0"},
+		{uint8(42), "en", "", "42"},
+		{" 42.10 ", "en", "", "42.1"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatNumber(tt.value, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatNumber(%v, %q, %q): unexpected error: %v", tt.value, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatNumber(%v, %q, %q): expected %q, got %q", tt.value, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestSymbolsCacheBounded(t *testing.T) {
+	for n := range maxCachedSymbols + 10 {
+		symbolsFor(fmt.Sprintf("en-x-test%d", n))
+	}
+	localeSymbols.mu.RLock()
+	size := len(localeSymbols.symbols)
+	localeSymbols.mu.RUnlock()
+	if size > maxCachedSymbols {
+		t.Errorf("expected at most %d cached locales, got %d", maxCachedSymbols, size)
+	}
+	if got := symbolsFor("en-x-uncached").group; got != "," {
+		t.Errorf("expected symbols beyond the cache limit, got group %q", got)
+	}
+}
+
+func TestFormatNumberErrors(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		opts  string
+	}{
+		{"abc", ""},
+		{math.NaN(), ""},
+		{[]int{1}, ""},
+		{1, "max"},
+		{1, "max=-1"},
+		{1, "round=sideways"},
+		{1, "grouping=maybe"},
+		{1, "style=short"},
+	}
+
+	for _, tt := range tests {
+		if _, err := formatNumber(tt.value, "en", tt.opts); err == nil {
+			t.Errorf("formatNumber(%v, %q): expected error, got nil", tt.value, tt.opts)
+		}
+	}
+}
+
+func TestToDecimalBounds(t *testing.T) {
+	for _, value := range []interface{}{
+		"1e10000000",
+		"1e-10000000",
+		json.Number("1e41"),
+		"12345678901234567890123456789012345678901",
+		strings.Repeat("9", 101),
+		1e300,
+		decimal.New(1, 100),
+	} {
+		if _, err := toDecimal(value); err == nil {
+			t.Errorf("toDecimal(%.30v): expected error, got nil", value)
+		}
+	}
+	for _, value := range []interface{}{"1e40", "1e-40", "1234567890123456789012345678901234567890", 1e20} {
+		if _, err := toDecimal(value); err != nil {
+			t.Errorf("toDecimal(%v): unexpected error: %v", value, err)
+		}
+	}
+
+	// The bound applies to every formatter that accepts user values.
+	if _, err := formatNumber("1e10000000", "en", ""); err == nil {
+		t.Error("formatNumber: expected error for huge exponent")
+	}
+	if _, err := formatCompact("1e10000000", "en", ""); err == nil {
+		t.Error("formatCompact: expected error for huge exponent")
+	}
+	if _, err := formatCurrency("1e10000000", "EUR", "en", ""); err == nil {
+		t.Error("formatCurrency: expected error for huge exponent")
+	}
+}
+
+func TestNumberPlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"error.invalidAmount": {"de": "Ungültiger Betrag: {0, number}", "en": "Invalid amount: {0, number}"},
+			"total":               {"de": "Summe: {0, number, min=2 max=2} ({1})"},
+			"unknown":             {"en": "Value: {0, roman}"},
+			"broken":              {"en": "Value: {0, number, max=x}"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	tFunc := i18n.CustomTemplateFunctions()["i18nTranslate"].(func(string, string, ...interface{}) (string, error))
+
+	tests := []struct {
+		key      string
+		lang     string
+		args     []interface{}
+		expected string
+	}{
+		{"error.invalidAmount", "de", []interface{}{"1500.99"}, "Ungültiger Betrag: 1.500,99"},
+		{"error.invalidAmount", "en", []interface{}{1500.99}, "Invalid amount: 1,500.99"},
+		{"error.invalidAmount", "fr", []interface{}{1500.99}, "Invalid amount: 1\u00a0500,99"},
+		{"total", "de", []interface{}{7, "netto"}, "Summe: 7,00 (netto)"},
+		{"total", "de", []interface{}{7}, "Summe: 7,00 ({1})"},
+		{"unknown", "en", []interface{}{4}, "Value: 4"},
+		{"broken", "en", []interface{}{4}, "Value: 4"},
+	}
+
+	for _, tt := range tests {
+		result, _ := tFunc(tt.key, tt.lang, tt.args...)
+		if result != tt.expected {
+			t.Errorf("%s/%s: expected %q, got %q", tt.key, tt.lang, tt.expected, result)
+		}
+	}
+}
+
+func TestI18nNumberFunction(t *testing.T) {
+	i18n := &I18n{}
+	numberFunc := i18n.CustomTemplateFunctions()["i18nNumber"].(func(interface{}, string, ...string) (string, error))
+
+	if result, err := numberFunc(1234.5, "de", "min=2", "max=2"); err != nil || result != "1.234,50" {
+		t.Errorf("expected '1.234,50', got %q (%v)", result, err)
+	}
+	if _, err := numberFunc("x", "de"); err == nil {
+		t.Error("expected error for invalid number, got nil")
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		lang     string
+		opts     string
+		expected string
+	}{
+		{0.12, "en", "", "12%"},
+		{0.12, "de", "", "12\u00a0%"},
+		{0.12, "fr", "", "12\u00a0%"},
+		{0.12, "tr", "", "%12"},
+		{-0.12, "de", "", "-12\u00a0%"},
+		{0.125, "en", "", "12%"},
+		{0.125, "de", "max=1", "12,5\u00a0%"},
+		{"0.0875", "en", "min=2", "8.75%"},
+		{12.5, "en", "", "1,250%"},
+		{1, "it", "", "100%"},
+		{-0.001, "en", "", "0%"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatPercent(tt.value, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatPercent(%v, %q, %q): unexpected error: %v", tt.value, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatPercent(%v, %q, %q): expected %q, got %q", tt.value, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+
+	if _, err := formatPercent("many", "en", ""); err == nil {
+		t.Error("expected error for invalid number, got nil")
+	}
+	if _, err := formatPercent(0.5, "en", "max=x"); err == nil {
+		t.Error("expected error for invalid option, got nil")
+	}
+}
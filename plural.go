@@ -0,0 +1,72 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralCategoryNames maps the plural forms of x/text to the CLDR plural
+// category names.
+var pluralCategoryNames = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// pluralCategory returns the CLDR plural category of the integer n in lang
+// according to rules, plural.Cardinal or plural.Ordinal.
+func pluralCategory(rules *plural.Rules, lang string, n int64) string {
+	if n < 0 {
+		n = -n
+	}
+	return decimalPluralCategory(rules, lang, strconv.FormatInt(n, 10), "")
+}
+
+// decimalPluralCategory returns the CLDR plural category in lang of the
+// number with the ASCII digits intPart and fracPart as displayed, e.g. "1"
+// and "50" for 1.50, which is "other" in English.
+func decimalPluralCategory(rules *plural.Rules, lang, intPart, fracPart string) string {
+	// The rules only look at the last digits of large numbers
+	if len(intPart) > 15 {
+		intPart = intPart[len(intPart)-15:]
+	}
+	i, _ := strconv.Atoi(intPart)
+	trimmed := strings.TrimRight(fracPart, "0")
+	f, _ := strconv.Atoi(fracPart)
+	t, _ := strconv.Atoi(trimmed)
+	form := rules.MatchPlural(language.Make(lang), i, len(fracPart), len(trimmed), f, t)
+	return pluralCategoryNames[form]
+}
+
+// pluralForms maps CLDR plural categories, or the selector values of select
+// forms in the dictionary, to messages.
+type pluralForms map[string]string
+
+// pick returns the message of category, or the one of "other" if there is
+// none.
+func (p pluralForms) pick(category string) string {
+	if msg, ok := p[category]; ok {
+		return msg
+	}
+	return p["other"]
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Pseudo-locales served when pseudo-localization is enabled.
+const (
+	// pseudoAccented is the accented, expanded and bracketed pseudo-locale.
+	pseudoAccented = "en-XA"
+
+	// pseudoBidi is the right-to-left mirrored pseudo-locale.
+	pseudoBidi = "ar-XB"
+)
+
+const (
+	// defaultPseudoExpansion is the default text expansion in percent.
+	defaultPseudoExpansion = 30
+
+	// Bidi control characters used for the mirrored pseudo-locale.
+	rlm = "\u200f" // RIGHT-TO-LEFT MARK
+	rlo = "\u202e" // RIGHT-TO-LEFT OVERRIDE
+	pdf = "\u202c" // POP DIRECTIONAL FORMATTING
+)
+
+// pseudoAccents maps ASCII letters to accented look-alikes.
+var pseudoAccents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î',
+	'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ',
+	's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î',
+	'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ',
+	'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// pseudoFiller provides the words appended to expand pseudo-localized text.
+var pseudoFiller = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+
+// PseudoConfig configures pseudo-localization. When enabled, the pseudo-locales
+// "en-XA" and "ar-XB" can be requested like any other language. They are
+// generated from the source language, so hard-coded strings and layout problems
+// become visible before real translations exist.
+//
+//   - en-XA: Letters are accented, the text is expanded and wrapped in brackets,
+//     e.g. "Hello {0}" becomes "[Ĥéļļö {0} one]".
+//   - ar-XB: The text is displayed right-to-left by wrapping it in bidi controls.
+//
+// Placeholders such as {0}, HTML tags and entities, and interpolated arguments
+// are left intact.
+type PseudoConfig struct {
+	// Expansion is the percentage by which en-XA text is lengthened to
+	// simulate languages with longer words. Defaults to 30; a negative
+	// value disables expansion.
+	Expansion int `json:"expansion,omitempty"`
+
+	// SourceLang is the language pseudo-locales are generated from.
+	// Defaults to "en".
+	SourceLang string `json:"source_lang,omitempty"`
+}
+
+// locale reports whether lang is a pseudo-locale served by this configuration.
+// A nil *PseudoConfig means pseudo-localization is disabled.
+func (p *PseudoConfig) locale(lang string) bool {
+	return p != nil && (lang == pseudoAccented || lang == pseudoBidi)
+}
+
+// sourceLang returns the language pseudo-locales are generated from.
+func (p *PseudoConfig) sourceLang() string {
+	if p.SourceLang == "" {
+		return "en"
+	}
+	return p.SourceLang
+}
+
+// expansion returns the effective expansion percentage.
+func (p *PseudoConfig) expansion() int {
+	switch {
+	case p.Expansion == 0:
+		return defaultPseudoExpansion
+	case p.Expansion < 0:
+		return 0
+	}
+	return p.Expansion
+}
+
+// transform pseudo-localizes a translation template for the given pseudo-locale.
+// It must be applied before interpolation so that arguments remain intact.
+func (p *PseudoConfig) transform(lang, tmpl string) string {
+	if lang == pseudoBidi {
+		return pseudoMirror(tmpl)
+	}
+
+	var b strings.Builder
+	visible := 0
+	forEachPseudoSegment(tmpl, func(text string, literal bool) {
+		if !literal {
+			b.WriteString(text)
+			return
+		}
+		for _, r := range text {
+			if accented, ok := pseudoAccents[r]; ok {
+				r = accented
+			}
+			b.WriteRune(r)
+			visible++
+		}
+	})
+
+	padding := (visible*p.expansion() + 99) / 100
+	if padding > 0 {
+		b.WriteString(pseudoPadding(padding))
+	}
+
+	return "[" + b.String() + "]"
+}
+
+// pseudoPadding returns filler words of at least n characters, including the
+// separating spaces.
+func pseudoPadding(n int) string {
+	var b strings.Builder
+	for i := 0; utf8.RuneCountInString(b.String()) < n; i++ {
+		b.WriteString(" ")
+		b.WriteString(pseudoFiller[i%len(pseudoFiller)])
+	}
+	return b.String()
+}
+
+// pseudoMirror wraps every literal text segment in right-to-left override
+// controls, leaving placeholders, markup and interpolated arguments outside.
+func pseudoMirror(tmpl string) string {
+	var b strings.Builder
+	forEachPseudoSegment(tmpl, func(text string, literal bool) {
+		if !literal || strings.TrimSpace(text) == "" {
+			b.WriteString(text)
+			return
+		}
+		b.WriteString(rlm + rlo + text + pdf + rlm)
+	})
+	return b.String()
+}
+
+// forEachPseudoSegment splits tmpl into literal text and protected segments.
+// Protected segments are placeholders like {0}, HTML tags and HTML entities.
+func forEachPseudoSegment(tmpl string, fn func(text string, literal bool)) {
+	start := 0
+	for i := 0; i < len(tmpl); {
+		end := protectedSegmentEnd(tmpl, i)
+		if end < 0 {
+			i++
+			continue
+		}
+		if start < i {
+			fn(tmpl[start:i], true)
+		}
+		fn(tmpl[i:end], false)
+		i, start = end, end
+	}
+	if start < len(tmpl) {
+		fn(tmpl[start:], true)
+	}
+}
+
+// protectedSegmentEnd returns the end offset of a placeholder, HTML tag or
+// HTML entity starting at offset i, or -1 if none starts there.
+func protectedSegmentEnd(s string, i int) int {
+	switch s[i] {
+	case '{':
+		if loc := placeholderRegexp.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+			return i + loc[1]
+		}
+	case '<':
+		if end := strings.IndexByte(s[i:], '>'); end > 0 {
+			return i + end + 1
+		}
+	case '&':
+		if end := strings.IndexByte(s[i:], ';'); end > 1 && !strings.ContainsAny(s[i+1:i+end], " &<") {
+			return i + end + 1
+		}
+	}
+	return -1
+}
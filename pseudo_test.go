@@ -0,0 +1,108 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPseudoAccentedLocale(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello":  {"de": "Hallo", "en": "Hello"},
+			"amount": {"en": "Amount: {0} for {1}"},
+			"bold":   {"en": "<b>Hi</b> &amp; bye"},
+			"system": {"en": "System"},
+		},
+		Pseudo: &PseudoConfig{Expansion: -1},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	translateFunc := funcMap["i18nTranslate"].(func(string, string, ...interface{}) (string, error))
+
+	tests := []struct {
+		key      string
+		args     []interface{}
+		expected string
+	}{
+		{"hello", nil, "[Ĥéļļö]"},
+		{"amount", []interface{}{"500.99", "i18n:system"}, "[Åɱöûñţ: 500.99 ƒöŕ System]"},
+		{"bold", nil, "[<b>Ĥî</b> &amp; ƀýé]"},
+		{"unknown", nil, "unknown"},
+	}
+
+	for _, tt := range tests {
+		result, err := translateFunc(tt.key, pseudoAccented, tt.args...)
+		if err != nil {
+			t.Errorf("unexpected error for key %s: %v", tt.key, err)
+		}
+		if result != tt.expected {
+			t.Errorf("key %s: expected %q, got %q", tt.key, tt.expected, result)
+		}
+	}
+}
+
+func TestPseudoExpansion(t *testing.T) {
+	p := &PseudoConfig{}
+
+	// 10 visible characters with the default 30% expansion need 3 extra characters
+	result := p.transform(pseudoAccented, "Hellohello")
+	if result != "[Ĥéļļöĥéļļö one]" {
+		t.Errorf("expected default expansion, got %q", result)
+	}
+
+	p.Expansion = 100
+	result = p.transform(pseudoAccented, "Hi {0}")
+	if result != "[Ĥî {0} one]" {
+		t.Errorf("expected placeholders to be excluded from expansion, got %q", result)
+	}
+}
+
+func TestPseudoBidiLocale(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"amount": {"de": "Betrag: {0}", "en": "Amount: {0}"},
+		},
+		Pseudo: &PseudoConfig{SourceLang: "de"},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	result := i18n.translate(nil, "amount", pseudoBidi, []interface{}{"42"})
+	expected := rlm + rlo + "Betrag: " + pdf + rlm + "42"
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestPseudoDisabled(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"en": "Hello"},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	// Without pseudo config, en-XA is an ordinary language and falls back to 'en'
+	if result := i18n.translate(nil, "hello", pseudoAccented, nil); result != "Hello" {
+		t.Errorf("expected plain fallback, got %q", result)
+	}
+}
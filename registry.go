@@ -0,0 +1,118 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// instances tracks every provisioned I18n instance so that the admin API
+// can report on the dictionaries that are currently loaded.
+var instances = &instanceRegistry{set: make(map[*I18n]struct{})}
+
+// internalDictionaries tracks the dictionaries loaded by other handlers, such
+// as i18n_bundle and i18n_negotiate, through newDictionary. They are reloaded
+// together with the instances after an edit, but not reported by the admin API.
+var internalDictionaries = &instanceRegistry{set: make(map[*I18n]struct{})}
+
+// instanceRegistry is a concurrency-safe set of provisioned I18n instances.
+type instanceRegistry struct {
+	mu  sync.RWMutex
+	set map[*I18n]struct{}
+}
+
+// add registers an instance. It is called from Provision.
+func (r *instanceRegistry) add(i *I18n) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.set[i] = struct{}{}
+}
+
+// remove unregisters an instance. It is called from Cleanup.
+func (r *instanceRegistry) remove(i *I18n) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.set, i)
+}
+
+// byDictFile returns one instance per configured dictionary file, sorted by
+// absolute path. Instances without a dictionary file are skipped, and when
+// several instances share a file, even under different relative paths, only
+// one of them is returned.
+func (r *instanceRegistry) byDictFile() []*I18n {
+	groups := r.groupedByDictFile()
+	result := make([]*I18n, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group[0])
+	}
+	return result
+}
+
+// groupedByDictFile returns all instances with a dictionary file, grouped by
+// file and sorted by absolute path. Several instances share a file when, for
+// example, multiple sites or templates handlers load the same dictionary.
+// Paths are compared after conversion to absolute paths, as in forDictFile.
+func (r *instanceRegistry) groupedByDictFile() [][]*I18n {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	groups := make(map[string][]*I18n)
+	for i := range r.set {
+		if i.DictFile != "" {
+			path := absPath(i.DictFile)
+			groups[path] = append(groups[path], i)
+		}
+	}
+
+	paths := make([]string, 0, len(groups))
+	for path := range groups {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := make([][]*I18n, 0, len(groups))
+	for _, path := range paths {
+		result = append(result, groups[path])
+	}
+	return result
+}
+
+// forDictFile returns all instances that loaded the given dictionary file.
+// Paths are compared after conversion to absolute paths.
+func (r *instanceRegistry) forDictFile(dictFile string) []*I18n {
+	target := absPath(dictFile)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*I18n
+	for i := range r.set {
+		if i.DictFile != "" && absPath(i.DictFile) == target {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// absPath returns the absolute form of path, or path itself if that fails.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryGroupsByAbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	registry := &instanceRegistry{set: make(map[*I18n]struct{})}
+	relative := &I18n{DictFile: "./t.json"}
+	absolute := &I18n{DictFile: filepath.Join(dir, "t.json")}
+	other := &I18n{DictFile: "x/t.json"}
+	for _, i := range []*I18n{relative, absolute, other, {}} {
+		registry.add(i)
+	}
+
+	groups := registry.groupedByDictFile()
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if len(groups[0]) != 2 || len(groups[1]) != 1 || groups[1][0] != other {
+		t.Errorf("expected ./t.json and its absolute path to share a group before x/t.json, got %v", groups)
+	}
+	if list := registry.byDictFile(); len(list) != 2 || list[1] != other {
+		t.Errorf("expected one instance per file, got %v", list)
+	}
+
+	if wd, err := os.Getwd(); err != nil || len(registry.forDictFile(filepath.Join(wd, "t.json"))) != 2 {
+		t.Errorf("expected forDictFile to find both instances (%v)", err)
+	}
+}
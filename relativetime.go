@@ -0,0 +1,261 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/feature/plural"
+)
+
+// relativeUnit is a unit of relative-time phrases with its approximate
+// length. Months and years use the average Gregorian lengths.
+type relativeUnit struct {
+	name   string
+	length time.Duration
+}
+
+// relativeUnits lists the units from the largest to the smallest.
+var relativeUnits = []relativeUnit{
+	{"year", 8765*time.Hour + 49*time.Minute + 12*time.Second},
+	{"month", 730*time.Hour + 29*time.Minute + 6*time.Second},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// relativeUnitPatterns holds the CLDR relative-time patterns of a unit.
+type relativeUnitPatterns struct {
+	future, past pluralForms
+
+	// named are the phrases for offsets with their own name, such as -1 for
+	// "yesterday" and 0 for "today".
+	named map[int]string
+}
+
+// relativeTimeData contains the long CLDR relative-time patterns of the
+// bundled locales, keyed by unit name.
+var relativeTimeData = map[string]map[string]relativeUnitPatterns{
+	"de": {
+		"year":   {pluralForms{"one": "in {0} Jahr", "other": "in {0} Jahren"}, pluralForms{"one": "vor {0} Jahr", "other": "vor {0} Jahren"}, map[int]string{-1: "letztes Jahr", 0: "dieses Jahr", 1: "nächstes Jahr"}},
+		"month":  {pluralForms{"one": "in {0} Monat", "other": "in {0} Monaten"}, pluralForms{"one": "vor {0} Monat", "other": "vor {0} Monaten"}, map[int]string{-1: "letzten Monat", 0: "diesen Monat", 1: "nächsten Monat"}},
+		"week":   {pluralForms{"one": "in {0} Woche", "other": "in {0} Wochen"}, pluralForms{"one": "vor {0} Woche", "other": "vor {0} Wochen"}, map[int]string{-1: "letzte Woche", 0: "diese Woche", 1: "nächste Woche"}},
+		"day":    {pluralForms{"one": "in {0} Tag", "other": "in {0} Tagen"}, pluralForms{"one": "vor {0} Tag", "other": "vor {0} Tagen"}, map[int]string{-2: "vorgestern", -1: "gestern", 0: "heute", 1: "morgen", 2: "übermorgen"}},
+		"hour":   {pluralForms{"one": "in {0} Stunde", "other": "in {0} Stunden"}, pluralForms{"one": "vor {0} Stunde", "other": "vor {0} Stunden"}, map[int]string{0: "in dieser Stunde"}},
+		"minute": {pluralForms{"one": "in {0} Minute", "other": "in {0} Minuten"}, pluralForms{"one": "vor {0} Minute", "other": "vor {0} Minuten"}, map[int]string{0: "in dieser Minute"}},
+		"second": {pluralForms{"one": "in {0} Sekunde", "other": "in {0} Sekunden"}, pluralForms{"one": "vor {0} Sekunde", "other": "vor {0} Sekunden"}, map[int]string{0: "jetzt"}},
+	},
+	"en": {
+		"year":   {pluralForms{"one": "in {0} year", "other": "in {0} years"}, pluralForms{"one": "{0} year ago", "other": "{0} years ago"}, map[int]string{-1: "last year", 0: "this year", 1: "next year"}},
+		"month":  {pluralForms{"one": "in {0} month", "other": "in {0} months"}, pluralForms{"one": "{0} month ago", "other": "{0} months ago"}, map[int]string{-1: "last month", 0: "this month", 1: "next month"}},
+		"week":   {pluralForms{"one": "in {0} week", "other": "in {0} weeks"}, pluralForms{"one": "{0} week ago", "other": "{0} weeks ago"}, map[int]string{-1: "last week", 0: "this week", 1: "next week"}},
+		"day":    {pluralForms{"one": "in {0} day", "other": "in {0} days"}, pluralForms{"one": "{0} day ago", "other": "{0} days ago"}, map[int]string{-1: "yesterday", 0: "today", 1: "tomorrow"}},
+		"hour":   {pluralForms{"one": "in {0} hour", "other": "in {0} hours"}, pluralForms{"one": "{0} hour ago", "other": "{0} hours ago"}, map[int]string{0: "this hour"}},
+		"minute": {pluralForms{"one": "in {0} minute", "other": "in {0} minutes"}, pluralForms{"one": "{0} minute ago", "other": "{0} minutes ago"}, map[int]string{0: "this minute"}},
+		"second": {pluralForms{"one": "in {0} second", "other": "in {0} seconds"}, pluralForms{"one": "{0} second ago", "other": "{0} seconds ago"}, map[int]string{0: "now"}},
+	},
+	"es": {
+		"year":   {pluralForms{"one": "dentro de {0} año", "other": "dentro de {0} años"}, pluralForms{"one": "hace {0} año", "other": "hace {0} años"}, map[int]string{-1: "el año pasado", 0: "este año", 1: "el próximo año"}},
+		"month":  {pluralForms{"one": "dentro de {0} mes", "other": "dentro de {0} meses"}, pluralForms{"one": "hace {0} mes", "other": "hace {0} meses"}, map[int]string{-1: "el mes pasado", 0: "este mes", 1: "el próximo mes"}},
+		"week":   {pluralForms{"one": "dentro de {0} semana", "other": "dentro de {0} semanas"}, pluralForms{"one": "hace {0} semana", "other": "hace {0} semanas"}, map[int]string{-1: "la semana pasada", 0: "esta semana", 1: "la próxima semana"}},
+		"day":    {pluralForms{"one": "dentro de {0} día", "other": "dentro de {0} días"}, pluralForms{"one": "hace {0} día", "other": "hace {0} días"}, map[int]string{-2: "anteayer", -1: "ayer", 0: "hoy", 1: "mañana", 2: "pasado mañana"}},
+		"hour":   {pluralForms{"one": "dentro de {0} hora", "other": "dentro de {0} horas"}, pluralForms{"one": "hace {0} hora", "other": "hace {0} horas"}, map[int]string{0: "esta hora"}},
+		"minute": {pluralForms{"one": "dentro de {0} minuto", "other": "dentro de {0} minutos"}, pluralForms{"one": "hace {0} minuto", "other": "hace {0} minutos"}, map[int]string{0: "este minuto"}},
+		"second": {pluralForms{"one": "dentro de {0} segundo", "other": "dentro de {0} segundos"}, pluralForms{"one": "hace {0} segundo", "other": "hace {0} segundos"}, map[int]string{0: "ahora"}},
+	},
+	"fr": {
+		"year":   {pluralForms{"one": "dans {0} an", "other": "dans {0} ans"}, pluralForms{"one": "il y a {0} an", "other": "il y a {0} ans"}, map[int]string{-1: "l’année dernière", 0: "cette année", 1: "l’année prochaine"}},
+		"month":  {pluralForms{"other": "dans {0} mois"}, pluralForms{"other": "il y a {0} mois"}, map[int]string{-1: "le mois dernier", 0: "ce mois-ci", 1: "le mois prochain"}},
+		"week":   {pluralForms{"one": "dans {0} semaine", "other": "dans {0} semaines"}, pluralForms{"one": "il y a {0} semaine", "other": "il y a {0} semaines"}, map[int]string{-1: "la semaine dernière", 0: "cette semaine", 1: "la semaine prochaine"}},
+		"day":    {pluralForms{"one": "dans {0} jour", "other": "dans {0} jours"}, pluralForms{"one": "il y a {0} jour", "other": "il y a {0} jours"}, map[int]string{-2: "avant-hier", -1: "hier", 0: "aujourd’hui", 1: "demain", 2: "après-demain"}},
+		"hour":   {pluralForms{"one": "dans {0} heure", "other": "dans {0} heures"}, pluralForms{"one": "il y a {0} heure", "other": "il y a {0} heures"}, map[int]string{0: "cette heure-ci"}},
+		"minute": {pluralForms{"one": "dans {0} minute", "other": "dans {0} minutes"}, pluralForms{"one": "il y a {0} minute", "other": "il y a {0} minutes"}, map[int]string{0: "cette minute-ci"}},
+		"second": {pluralForms{"one": "dans {0} seconde", "other": "dans {0} secondes"}, pluralForms{"one": "il y a {0} seconde", "other": "il y a {0} secondes"}, map[int]string{0: "maintenant"}},
+	},
+	"it": {
+		"year":   {pluralForms{"one": "tra {0} anno", "other": "tra {0} anni"}, pluralForms{"one": "{0} anno fa", "other": "{0} anni fa"}, map[int]string{-1: "anno scorso", 0: "quest’anno", 1: "anno prossimo"}},
+		"month":  {pluralForms{"one": "tra {0} mese", "other": "tra {0} mesi"}, pluralForms{"one": "{0} mese fa", "other": "{0} mesi fa"}, map[int]string{-1: "mese scorso", 0: "questo mese", 1: "mese prossimo"}},
+		"week":   {pluralForms{"one": "tra {0} settimana", "other": "tra {0} settimane"}, pluralForms{"one": "{0} settimana fa", "other": "{0} settimane fa"}, map[int]string{-1: "settimana scorsa", 0: "questa settimana", 1: "settimana prossima"}},
+		"day":    {pluralForms{"one": "tra {0} giorno", "other": "tra {0} giorni"}, pluralForms{"one": "{0} giorno fa", "other": "{0} giorni fa"}, map[int]string{-2: "l’altro ieri", -1: "ieri", 0: "oggi", 1: "domani", 2: "dopodomani"}},
+		"hour":   {pluralForms{"one": "tra {0} ora", "other": "tra {0} ore"}, pluralForms{"one": "{0} ora fa", "other": "{0} ore fa"}, map[int]string{0: "quest’ora"}},
+		"minute": {pluralForms{"one": "tra {0} minuto", "other": "tra {0} minuti"}, pluralForms{"one": "{0} minuto fa", "other": "{0} minuti fa"}, map[int]string{0: "questo minuto"}},
+		"second": {pluralForms{"one": "tra {0} secondo", "other": "tra {0} secondi"}, pluralForms{"one": "{0} secondo fa", "other": "{0} secondi fa"}, map[int]string{0: "ora"}},
+	},
+	"nl": {
+		"year":   {pluralForms{"other": "over {0} jaar"}, pluralForms{"other": "{0} jaar geleden"}, map[int]string{-1: "vorig jaar", 0: "dit jaar", 1: "volgend jaar"}},
+		"month":  {pluralForms{"one": "over {0} maand", "other": "over {0} maanden"}, pluralForms{"one": "{0} maand geleden", "other": "{0} maanden geleden"}, map[int]string{-1: "vorige maand", 0: "deze maand", 1: "volgende maand"}},
+		"week":   {pluralForms{"one": "over {0} week", "other": "over {0} weken"}, pluralForms{"one": "{0} week geleden", "other": "{0} weken geleden"}, map[int]string{-1: "vorige week", 0: "deze week", 1: "volgende week"}},
+		"day":    {pluralForms{"one": "over {0} dag", "other": "over {0} dagen"}, pluralForms{"one": "{0} dag geleden", "other": "{0} dagen geleden"}, map[int]string{-2: "eergisteren", -1: "gisteren", 0: "vandaag", 1: "morgen", 2: "overmorgen"}},
+		"hour":   {pluralForms{"other": "over {0} uur"}, pluralForms{"other": "{0} uur geleden"}, map[int]string{0: "binnen een uur"}},
+		"minute": {pluralForms{"one": "over {0} minuut", "other": "over {0} minuten"}, pluralForms{"one": "{0} minuut geleden", "other": "{0} minuten geleden"}, map[int]string{0: "binnen een minuut"}},
+		"second": {pluralForms{"one": "over {0} seconde", "other": "over {0} seconden"}, pluralForms{"one": "{0} seconde geleden", "other": "{0} seconden geleden"}, map[int]string{0: "nu"}},
+	},
+	"pt": {
+		"year":   {pluralForms{"one": "em {0} ano", "other": "em {0} anos"}, pluralForms{"one": "há {0} ano", "other": "há {0} anos"}, map[int]string{-1: "ano passado", 0: "este ano", 1: "próximo ano"}},
+		"month":  {pluralForms{"one": "em {0} mês", "other": "em {0} meses"}, pluralForms{"one": "há {0} mês", "other": "há {0} meses"}, map[int]string{-1: "mês passado", 0: "este mês", 1: "próximo mês"}},
+		"week":   {pluralForms{"one": "em {0} semana", "other": "em {0} semanas"}, pluralForms{"one": "há {0} semana", "other": "há {0} semanas"}, map[int]string{-1: "semana passada", 0: "esta semana", 1: "próxima semana"}},
+		"day":    {pluralForms{"one": "em {0} dia", "other": "em {0} dias"}, pluralForms{"one": "há {0} dia", "other": "há {0} dias"}, map[int]string{-2: "anteontem", -1: "ontem", 0: "hoje", 1: "amanhã", 2: "depois de amanhã"}},
+		"hour":   {pluralForms{"one": "em {0} hora", "other": "em {0} horas"}, pluralForms{"one": "há {0} hora", "other": "há {0} horas"}, map[int]string{0: "esta hora"}},
+		"minute": {pluralForms{"one": "em {0} minuto", "other": "em {0} minutos"}, pluralForms{"one": "há {0} minuto", "other": "há {0} minutos"}, map[int]string{0: "este minuto"}},
+		"second": {pluralForms{"one": "em {0} segundo", "other": "em {0} segundos"}, pluralForms{"one": "há {0} segundo", "other": "há {0} segundos"}, map[int]string{0: "agora"}},
+	},
+}
+
+// relativeTimeOptions controls how a relative time is formatted.
+type relativeTimeOptions struct {
+	// unit is the unit to use, or "" to pick the largest unit that fits.
+	unit string
+
+	// granularity is the smallest unit picked automatically.
+	granularity string
+
+	// numeric is false to use names such as "yesterday" instead of
+	// "1 day ago" where the locale has them.
+	numeric bool
+
+	// now is the reference time, or the zero time for the current time.
+	now time.Time
+}
+
+// parseRelativeTimeOptions parses space-separated options: unit=<unit> to
+// use a fixed unit, granularity=<unit> for the smallest unit picked
+// automatically, numeric=auto|always and now=<time> for the reference time.
+// Units are year, month, week, day, hour, minute and second.
+func parseRelativeTimeOptions(opts string) (relativeTimeOptions, error) {
+	result := relativeTimeOptions{granularity: "second", numeric: true}
+	for _, opt := range strings.Fields(opts) {
+		key, val, _ := strings.Cut(opt, "=")
+		switch key {
+		case "unit", "granularity":
+			if relativeUnitLength(val) == 0 {
+				return result, fmt.Errorf("invalid relative time option %q: unknown unit", opt)
+			}
+			if key == "unit" {
+				result.unit = val
+			} else {
+				result.granularity = val
+			}
+		case "numeric":
+			if val != "auto" && val != "always" {
+				return result, fmt.Errorf("invalid relative time option %q: must be auto or always", opt)
+			}
+			result.numeric = val == "always"
+		case "now":
+			now, err := toTime(val)
+			if err != nil {
+				return result, fmt.Errorf("invalid relative time option %q: %v", opt, err)
+			}
+			result.now = now
+		default:
+			return result, fmt.Errorf("unknown relative time option %q", opt)
+		}
+	}
+	return result, nil
+}
+
+// relativeUnitLength returns the length of the unit with the given name, or
+// 0 if there is none.
+func relativeUnitLength(name string) time.Duration {
+	for _, unit := range relativeUnits {
+		if unit.name == name {
+			return unit.length
+		}
+	}
+	return 0
+}
+
+// toOffset converts a template value to an offset from now: a time.Duration
+// or a duration string such as "-72h" is used as is, and times as accepted by
+// toTime are relative to now.
+func toOffset(value interface{}, now time.Time) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		if d, err := time.ParseDuration(strings.TrimSpace(v)); err == nil {
+			return d, nil
+		}
+	}
+	t, err := toTime(value)
+	if err != nil {
+		return 0, fmt.Errorf("%v or duration", err)
+	}
+	return t.Sub(now), nil
+}
+
+// formatRelativeTime formats value in lang as a phrase such as "in 2 hours"
+// or "vor 3 Tagen", relative to now unless opts contain another reference
+// time. By default, the unit is the largest one whose length the offset
+// reaches, and the count is rounded to the nearest integer. A count of 0
+// uses the locale's name for it, such as "now" or "today".
+func formatRelativeTime(value interface{}, now time.Time, lang, opts string) (string, error) {
+	options, err := parseRelativeTimeOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	if !options.now.IsZero() {
+		now = options.now
+	}
+	offset, err := toOffset(value, now)
+	if err != nil {
+		return "", err
+	}
+
+	unit := options.unit
+	if unit == "" {
+		abs := offset.Abs()
+		for _, u := range relativeUnits {
+			unit = u.name
+			if abs >= u.length || u.name == options.granularity {
+				break
+			}
+		}
+	}
+	count := int64(math.Round(float64(offset) / float64(relativeUnitLength(unit))))
+
+	locale, ok := findLocale(relativeTimeData, lang)
+	if !ok {
+		// A locale without bundled patterns is formatted entirely in
+		// English, so the plural category and the digits match the
+		// English words.
+		lang = "en"
+	}
+	patterns := locale[unit]
+	if name, ok := patterns.named[int(count)]; ok && (count == 0 || !options.numeric) {
+		return name, nil
+	}
+	forms := patterns.future
+	if count < 0 {
+		forms = patterns.past
+	}
+	sym := symbolsFor(lang)
+	n := sym.formatDecimal(decimal.NewFromInt(count), defaultNumberOptions)
+	return strings.Replace(forms.pick(pluralCategory(plural.Cardinal, lang, count)), "{0}", n, 1), nil
+}
+
+// currentTime returns the reference time for relative times: the result of
+// i.now if set, and time.Now otherwise.
+func (i *I18n) currentTime() time.Time {
+	if i.now != nil {
+		return i.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    interface{}
+		lang     string
+		opts     string
+		expected string
+	}{
+		{now.Add(-3 * 24 * time.Hour), "de", "", "vor 3 Tagen"},
+		{now.Add(-24 * time.Hour), "de", "", "vor 1 Tag"},
+		{now.Add(-24 * time.Hour), "de", "numeric=auto", "gestern"},
+		{now.Add(48 * time.Hour), "de", "numeric=auto", "übermorgen"},
+		{now.Add(2 * time.Hour), "en", "", "in 2 hours"},
+		{now.Add(-time.Minute), "en", "", "1 minute ago"},
+		{now.Add(-90 * time.Second), "en", "", "2 minutes ago"},
+		{now.Add(-10 * time.Second), "en", "", "10 seconds ago"},
+		{now, "en", "", "now"},
+		{now, "de", "", "jetzt"},
+		{now.Add(-400 * time.Millisecond), "fr", "", "maintenant"},
+		{now.Add(-2 * time.Hour), "en", "granularity=day", "today"},
+		{now.Add(-20 * time.Hour), "en", "granularity=day", "1 day ago"},
+		{now.Add(-20 * time.Hour), "en", "granularity=day numeric=auto", "yesterday"},
+		{now.Add(-36 * time.Hour), "en", "unit=hour", "36 hours ago"},
+		{now.Add(-10 * 24 * time.Hour), "en", "", "1 week ago"},
+		{now.Add(-45 * 24 * time.Hour), "es", "", "hace 1 mes"},
+		{now.Add(-90 * 24 * time.Hour), "fr", "", "il y a 3 mois"},
+		{now.Add(-400 * 24 * time.Hour), "nl", "", "1 jaar geleden"},
+		{now.Add(-2000 * 24 * time.Hour), "en", "unit=day", "2,000 days ago"},
+		{now.Add(-2000 * 24 * time.Hour), "de", "unit=day", "vor 2.000 Tagen"},
+		{now.Add(5 * time.Minute), "it", "", "tra 5 minuti"},
+		{now.Add(-time.Minute), "pt-BR", "", "há 1 minuto"},
+		{now.Add(-time.Hour), "sv", "", "1 hour ago"},
+
+		// Unbundled locales fall back to English entirely, including the
+		// plural category and the digits
+		{now.Add(-24 * time.Hour), "ja", "", "1 day ago"},
+		{now.Add(-3 * 24 * time.Hour), "ja", "", "3 days ago"},
+		{now.Add(-24 * time.Hour), "ar", "", "1 day ago"},
+		{now.Add(-2000 * 24 * time.Hour), "ar", "unit=day", "2,000 days ago"},
+
+		// French uses the singular for 0 and 1
+		{now.Add(-24 * time.Hour), "fr", "", "il y a 1 jour"},
+		{now.Add(-3 * time.Hour), "fr", "unit=day", "aujourd’hui"},
+
+		// Durations, timestamps and strings
+		{-72 * time.Hour, "de", "", "vor 3 Tagen"},
+		{"90m", "en", "", "in 2 hours"},
+		{"-1h30m", "en", "unit=minute", "90 minutes ago"},
+		{now.Unix() - 3600, "en", "", "1 hour ago"},
+		{"2025-03-18", "en", "granularity=day", "in 4 days"},
+		{"2025-03-14T10:00:00Z", "en", "now=2025-03-14T09:00:00Z", "in 1 hour"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatRelativeTime(tt.value, now, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatRelativeTime(%v, %q, %q): unexpected error: %v", tt.value, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatRelativeTime(%v, %q, %q): expected %q, got %q", tt.value, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatRelativeTimeErrors(t *testing.T) {
+	now := time.Now()
+	for _, opts := range []string{"unit=fortnight", "granularity=", "numeric=sometimes", "now=yesterday", "style=short"} {
+		if _, err := formatRelativeTime(now, now, "en", opts); err == nil {
+			t.Errorf("expected error for options %q, got nil", opts)
+		}
+	}
+	if _, err := formatRelativeTime("soon", now, "en", ""); err == nil {
+		t.Error("expected error for unparsable value, got nil")
+	}
+}
+
+func TestRelativeTimePlaceholder(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"comment.posted": {
+				"de": "Kommentiert {0, relative}",
+				"en": "Commented {0, relative, granularity=day numeric=auto}",
+			},
+		},
+		now: func() time.Time { return time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC) },
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	arg := []interface{}{"2025-03-13T09:00:00Z"}
+	if result := i18n.translate(nil, "comment.posted", "de", arg); result != "Kommentiert vor 1 Tag" {
+		t.Errorf("unexpected German result %q", result)
+	}
+	if result := i18n.translate(nil, "comment.posted", "en", arg); result != "Commented yesterday" {
+		t.Errorf("unexpected English result %q", result)
+	}
+}
+
+func TestI18nRelativeTimeFunction(t *testing.T) {
+	now := time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC)
+	i18n := &I18n{now: func() time.Time { return now }}
+	relFunc := i18n.CustomTemplateFunctions()["i18nRelativeTime"].(func(interface{}, string, ...string) (string, error))
+
+	if result, err := relFunc(now.Add(-3*24*time.Hour), "de"); err != nil || result != "vor 3 Tagen" {
+		t.Errorf("expected 'vor 3 Tagen', got %q (%v)", result, err)
+	}
+	if result, err := relFunc(now.Add(2*time.Hour), "en", "unit=minute"); err != nil || result != "in 120 minutes" {
+		t.Errorf("expected 'in 120 minutes', got %q (%v)", result, err)
+	}
+
+	// Without an injected clock, the current time is used
+	if result, err := (&I18n{}).CustomTemplateFunctions()["i18nRelativeTime"].(func(interface{}, string, ...string) (string, error))(time.Now(), "en"); err != nil || result != "now" {
+		t.Errorf("expected 'now', got %q (%v)", result, err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+)
+
+// defaultLangPlaceholders are the placeholders consulted by i18nT if
+// lang_placeholders is not configured.
+var defaultLangPlaceholders = []string{"{http.vars.lang}", "{http.i18n.lang}"}
+
+// langVaryRegexp finds the request cookie and header placeholders in a
+// lang_placeholders entry, e.g. {http.request.cookie.lang} or
+// {http.request.header.Accept-Language}.
+var langVaryRegexp = regexp.MustCompile(`\{http\.request\.(cookie|header)\.([^{}]+)\}`)
+
+// requestLang determines the language of a request from the configured
+// LangPlaceholders. The first placeholder that resolves to a non-empty value
+// wins. If none does, or r is nil, "en" is returned.
+func (i *I18n) requestLang(r *http.Request) string {
+	lang, _ := i.langFromPlaceholders(r)
+	return lang
+}
+
+// templateLang determines the language of the template's request like
+// requestLang. If the language was read from a request cookie or header, the
+// matching field is added to the Vary response header, so that shared caches
+// don't serve the page in the wrong language.
+func (i *I18n) templateLang(ctx *templates.TemplateContext) string {
+	lang, vary := i.langFromPlaceholders(requestOf(ctx))
+	if ctx != nil && ctx.RespHeader.Header != nil {
+		for _, field := range vary {
+			addVaryHeader(ctx.RespHeader.Header, field)
+		}
+	}
+	return lang
+}
+
+// langFromPlaceholders implements requestLang. It also returns the Vary
+// fields of the request cookies and headers consulted up to the placeholder
+// that determined the language.
+func (i *I18n) langFromPlaceholders(r *http.Request) (lang string, vary []string) {
+	if r == nil {
+		return "en", nil
+	}
+	repl, ok := r.Context().Value(caddy.ReplacerCtxKey).(*caddy.Replacer)
+	if !ok {
+		return "en", nil
+	}
+
+	placeholders := i.LangPlaceholders
+	if len(placeholders) == 0 {
+		placeholders = defaultLangPlaceholders
+	}
+	for _, placeholder := range placeholders {
+		for _, match := range langVaryRegexp.FindAllStringSubmatch(placeholder, -1) {
+			field := "Cookie"
+			if match[1] == "header" {
+				field = http.CanonicalHeaderKey(match[2])
+			}
+			if !slices.Contains(vary, field) {
+				vary = append(vary, field)
+			}
+		}
+		if lang := strings.TrimSpace(repl.ReplaceAll(placeholder, "")); lang != "" {
+			return lang, vary
+		}
+	}
+	return "en", vary
+}
+
+// recordContentLanguage adds the language a lookup's text is in, as returned
+// by lookup, to the Content-Language header of the response, unless
+// ContentLanguage is disabled. A translation that fell back to English is
+// recorded as 'en'; a key returned as its own fallback is not recorded. Each
+// language is listed once, in order of first use.
+func (i *I18n) recordContentLanguage(ctx *templates.TemplateContext, lang string) {
+	if ctx == nil || ctx.RespHeader.Header == nil || lang == "" {
+		return
+	}
+	if i.ContentLanguage != nil && !*i.ContentLanguage {
+		return
+	}
+
+	header := ctx.RespHeader.Header
+	current := header.Get("Content-Language")
+	for _, existing := range strings.Split(current, ",") {
+		if strings.TrimSpace(existing) == lang {
+			return
+		}
+	}
+	if current == "" {
+		header.Set("Content-Language", lang)
+	} else {
+		header.Set("Content-Language", current+", "+lang)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+// newTestRequest returns a request with a replacer holding the given static values.
+func newTestRequest(target string, values map[string]string) (*http.Request, *caddy.Replacer) {
+	repl := caddy.NewReplacer()
+	for k, v := range values {
+		repl.Set(k, v)
+	}
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r = r.WithContext(context.WithValue(r.Context(), caddy.ReplacerCtxKey, repl))
+	return r, repl
+}
+
+func TestI18nTUsesLangPlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello":  {"de": "Hallo", "en": "Hello", "fr": "Bonjour"},
+			"amount": {"de": "Betrag: {0}", "en": "Amount: {0}"},
+		},
+		LangPlaceholders: []string{"{http.vars.lang}", "{test.cookie.lang}"},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	tFunc := funcMap["i18nT"].(func(*templates.TemplateContext, string, ...interface{}) (string, error))
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		key      string
+		args     []interface{}
+		expected string
+	}{
+		{"first placeholder", map[string]string{"http.vars.lang": "de", "test.cookie.lang": "fr"}, "hello", nil, "Hallo"},
+		{"second placeholder", map[string]string{"test.cookie.lang": "fr"}, "hello", nil, "Bonjour"},
+		{"default", nil, "hello", nil, "Hello"},
+		{"fallback to en", map[string]string{"http.vars.lang": "it"}, "hello", nil, "Hello"},
+		{"with args", map[string]string{"http.vars.lang": " de "}, "amount", []interface{}{"42"}, "Betrag: 42"},
+	}
+
+	for _, tt := range tests {
+		r, _ := newTestRequest("/", tt.values)
+		result, err := tFunc(&templates.TemplateContext{Req: r}, tt.key, tt.args...)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestRequestLangWithoutReplacer(t *testing.T) {
+	i18n := &I18n{}
+
+	if lang := i18n.requestLang(nil); lang != "en" {
+		t.Errorf("expected 'en' without request, got %q", lang)
+	}
+	if lang := i18n.requestLang(httptest.NewRequest(http.MethodGet, "/", nil)); lang != "en" {
+		t.Errorf("expected 'en' without replacer, got %q", lang)
+	}
+}
+
+func TestI18nSetsContentLanguage(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"hello": {"de": "Hallo", "en": "Hello"},
+			"bye":   {"en": "Goodbye"},
+		},
+		langCounts: map[string]int{"de": 1, "en": 2},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	funcMap := i18n.CustomTemplateFunctions()
+	tFunc := funcMap["i18nT"].(func(*templates.TemplateContext, string, ...interface{}) (string, error))
+	ctxFunc := funcMap["i18nTranslateCtx"].(func(*templates.TemplateContext, string, string, ...interface{}) (string, error))
+
+	r, _ := newTestRequest("/", map[string]string{"http.vars.lang": "de"})
+	header := make(http.Header)
+	ctx := &templates.TemplateContext{Req: r, RespHeader: templates.WrappedHeader{Header: header}}
+
+	// Keys returned as their own fallback are not recorded.
+	tFunc(ctx, "hello")
+	tFunc(ctx, "unknown")
+	if got := header.Get("Content-Language"); got != "de" {
+		t.Errorf("expected Content-Language 'de', got %q", got)
+	}
+
+	// A key without a German translation renders in the 'en' fallback.
+	tFunc(ctx, "bye")
+	if got := header.Get("Content-Language"); got != "de, en" {
+		t.Errorf("expected Content-Language 'de, en', got %q", got)
+	}
+
+	// As do languages not in the dictionary.
+	header.Del("Content-Language")
+	ctxFunc(ctx, "hello", "it")
+	if got := header.Get("Content-Language"); got != "en" {
+		t.Errorf("expected Content-Language 'en', got %q", got)
+	}
+}
+
+func TestI18nTVaryForCookieAndHeaderPlaceholders(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{"hello": {"de": "Hallo", "en": "Hello", "fr": "Bonjour"}},
+		LangPlaceholders: []string{
+			"{http.vars.lang}",
+			"{http.request.cookie.lang}",
+			"{http.request.header.accept-language}",
+			"{http.request.header.X-Lang}",
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	tFunc := i18n.CustomTemplateFunctions()["i18nT"].(func(*templates.TemplateContext, string, ...interface{}) (string, error))
+
+	tests := []struct {
+		name         string
+		cookie       string
+		header       string
+		vars         string
+		expectedText string
+		expectedVary []string
+	}{
+		{"from cookie", "fr", "de", "", "Bonjour", []string{"Cookie"}},
+		{"from header", "", "de", "", "Hallo", []string{"Cookie", "Accept-Language"}},
+		{"all consulted", "", "", "", "Hello", []string{"Cookie", "Accept-Language", "X-Lang"}},
+		{"from vars", "fr", "de", "de", "Hallo", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set("Accept-Language", tt.header)
+			}
+			repl := caddyhttp.NewTestReplacer(r)
+			if tt.vars != "" {
+				repl.Set("http.vars.lang", tt.vars)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), caddy.ReplacerCtxKey, repl))
+
+			header := make(http.Header)
+			ctx := &templates.TemplateContext{Req: r, RespHeader: templates.WrappedHeader{Header: header}}
+			if got, _ := tFunc(ctx, "hello"); got != tt.expectedText {
+				t.Errorf("expected %q, got %q", tt.expectedText, got)
+			}
+			if got := header.Values("Vary"); !slices.Equal(got, tt.expectedVary) {
+				t.Errorf("expected Vary %v, got %v", tt.expectedVary, got)
+			}
+		})
+	}
+}
+
+func TestI18nContentLanguageDisabled(t *testing.T) {
+	disabled := false
+	i18n := &I18n{
+		translations:    map[string]map[string]string{"hello": {"de": "Hallo"}},
+		ContentLanguage: &disabled,
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	ctxFunc := i18n.CustomTemplateFunctions()["i18nTranslateCtx"].(func(*templates.TemplateContext, string, string, ...interface{}) (string, error))
+
+	header := make(http.Header)
+	ctx := &templates.TemplateContext{RespHeader: templates.WrappedHeader{Header: header}}
+	ctxFunc(ctx, "hello", "de")
+	if got := header.Get("Content-Language"); got != "" {
+		t.Errorf("expected no Content-Language, got %q", got)
+	}
+}
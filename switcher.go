@@ -0,0 +1,104 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// LanguageOption describes one entry of a language switcher, as returned by
+// i18nLanguages.
+type LanguageOption struct {
+	// Code is the language code as used in the dictionary, e.g. "de".
+	Code string `json:"code"`
+
+	// NativeName is the name of the language in itself, e.g. "Deutsch".
+	NativeName string `json:"native_name"`
+
+	// Name is the name of the language in the current language, e.g.
+	// "German" on an English page. Unlike NativeName, it keeps the CLDR
+	// capitalization, e.g. "allemand" on a French page.
+	Name string `json:"name"`
+
+	// Dir is the text direction of the language, "ltr" or "rtl".
+	Dir string `json:"dir"`
+
+	// URL is the URL of the current page in the language.
+	URL string `json:"url"`
+
+	// Current reports whether the language is the current language.
+	Current bool `json:"current"`
+}
+
+// languageOptions returns a LanguageOption for every language of the loaded
+// dictionary, marking current, which i18nLanguages determines like i18nT. The
+// URLs are built according to LocaleURLs. Names come from the CLDR data of
+// golang.org/x/text; if CLDR has no name, the code is used instead.
+func (i *I18n) languageOptions(r *http.Request, current string) []LanguageOption {
+	currentNamer := display.Tags(language.Make(current))
+
+	var neutral *url.URL
+	if r != nil {
+		neutral = i.neutralURL(r)
+	}
+
+	langs := i.supportedLanguages()
+	options := make([]LanguageOption, 0, len(langs))
+	for _, lang := range langs {
+		tag := language.Make(lang)
+		option := LanguageOption{
+			Code:       lang,
+			NativeName: capitalizeFirst(display.Self.Name(tag)),
+			Dir:        languageDirection(lang),
+			Current:    strings.EqualFold(lang, current),
+		}
+		// CLDR names languages it doesn't know "Unknown language".
+		if currentNamer != nil && currentNamer.Name(tag) != currentNamer.Name(language.Und) {
+			option.Name = currentNamer.Name(tag)
+		}
+		if option.NativeName == "" {
+			option.NativeName = option.Name
+		}
+		if option.NativeName == "" {
+			option.NativeName = lang
+		}
+		if option.Name == "" {
+			option.Name = option.NativeName
+		}
+		if neutral != nil {
+			option.URL = i.localizedURL(neutral, lang)
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// capitalizeFirst upper-cases the first letter of s, since CLDR lists most
+// language names in lowercase, e.g. "français", while switchers show them on
+// their own.
+func capitalizeFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + s[size:]
+}
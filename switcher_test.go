@@ -0,0 +1,85 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestI18nLanguages(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{"hello": {"ar": "مرحبا", "de": "Hallo", "fr": "Bonjour"}},
+		langCounts:   map[string]int{"ar": 1, "de": 1, "fr": 1},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	langFunc := i18n.CustomTemplateFunctions()["i18nLanguages"].(func(*templates.TemplateContext) ([]LanguageOption, error))
+
+	req, _ := newTestRequest("https://example.com/de/about", map[string]string{"http.vars.lang": "de"})
+	options, err := langFunc(&templates.TemplateContext{Req: req})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []LanguageOption{
+		{Code: "ar", NativeName: "العربية", Name: "Arabisch", Dir: "rtl", URL: "https://example.com/ar/about"},
+		{Code: "de", NativeName: "Deutsch", Name: "Deutsch", Dir: "ltr", URL: "https://example.com/de/about", Current: true},
+		{Code: "fr", NativeName: "Français", Name: "Französisch", Dir: "ltr", URL: "https://example.com/fr/about"},
+	}
+	if len(options) != len(expected) {
+		t.Fatalf("expected %d options, got %+v", len(expected), options)
+	}
+	for idx, option := range options {
+		if option != expected[idx] {
+			t.Errorf("expected %+v, got %+v", expected[idx], option)
+		}
+	}
+}
+
+func TestI18nLanguagesUnknownNames(t *testing.T) {
+	i18n := &I18n{langCounts: map[string]int{"x-klingon": 1, "xx": 1}}
+	i18n.mu = new(sync.RWMutex)
+
+	options := i18n.languageOptions(httptest.NewRequest(http.MethodGet, "/", nil), "en")
+	if len(options) != 2 {
+		t.Fatalf("expected two options, got %+v", options)
+	}
+	for _, option := range options {
+		if option.NativeName != option.Code || option.Name != option.Code || option.Dir != "ltr" {
+			t.Errorf("expected code as name and ltr, got %+v", option)
+		}
+	}
+}
+
+func TestCapitalizeFirst(t *testing.T) {
+	tests := map[string]string{
+		"français": "Français",
+		"Deutsch":  "Deutsch",
+		"ελληνικά": "Ελληνικά",
+		"":         "",
+	}
+	for input, expected := range tests {
+		if result := capitalizeFirst(input); result != expected {
+			t.Errorf("capitalizeFirst(%q): expected %q, got %q", input, expected, result)
+		}
+	}
+}
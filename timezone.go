@@ -0,0 +1,397 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// zoneNames holds the CLDR names of a time zone: the long standard and
+// daylight names, e.g. "Central European Standard Time", followed by the
+// short ones, e.g. "EST". Names a locale doesn't have are empty.
+type zoneNames [4]string
+
+// metazoneZones lists the IANA time zones of each CLDR metazone, the group
+// of zones that share names such as "Central European Time". Zones that
+// aren't listed are shown in the localized GMT format.
+var metazoneZones = map[string][]string{
+	"Europe_Central": {
+		"Africa/Algiers", "Africa/Ceuta", "Africa/Tunis", "Arctic/Longyearbyen", "CET", "Europe/Amsterdam",
+		"Europe/Andorra", "Europe/Belgrade", "Europe/Berlin", "Europe/Bratislava", "Europe/Brussels",
+		"Europe/Budapest", "Europe/Busingen", "Europe/Copenhagen", "Europe/Gibraltar", "Europe/Ljubljana",
+		"Europe/Luxembourg", "Europe/Madrid", "Europe/Malta", "Europe/Monaco", "Europe/Oslo", "Europe/Paris",
+		"Europe/Podgorica", "Europe/Prague", "Europe/Rome", "Europe/San_Marino", "Europe/Sarajevo", "Europe/Skopje",
+		"Europe/Stockholm", "Europe/Tirane", "Europe/Vaduz", "Europe/Vatican", "Europe/Vienna", "Europe/Warsaw",
+		"Europe/Zagreb", "Europe/Zurich",
+	},
+	"Europe_Western": {
+		"Atlantic/Canary", "Atlantic/Faroe", "Atlantic/Madeira", "Europe/Lisbon", "WET",
+	},
+	"Europe_Eastern": {
+		"Africa/Cairo", "Africa/Tripoli", "Asia/Beirut", "Asia/Famagusta", "Asia/Nicosia", "EET", "Europe/Athens",
+		"Europe/Bucharest", "Europe/Chisinau", "Europe/Helsinki", "Europe/Kaliningrad", "Europe/Kiev", "Europe/Kyiv",
+		"Europe/Mariehamn", "Europe/Riga", "Europe/Sofia", "Europe/Tallinn", "Europe/Vilnius",
+	},
+	"GMT": {
+		"Africa/Abidjan", "Africa/Accra", "Africa/Bamako", "Africa/Banjul", "Africa/Bissau", "Africa/Conakry",
+		"Africa/Dakar", "Africa/Freetown", "Africa/Lome", "Africa/Monrovia", "Africa/Nouakchott",
+		"Africa/Ouagadougou", "Atlantic/Reykjavik", "Atlantic/St_Helena", "Etc/GMT", "Europe/Guernsey",
+		"Europe/Isle_of_Man", "Europe/Jersey", "Europe/London", "GMT",
+	},
+	"Moscow": {
+		"Europe/Minsk", "Europe/Moscow", "Europe/Simferopol",
+	},
+	"America_Eastern": {
+		"America/Cancun", "America/Detroit", "America/Indiana/Indianapolis", "America/Indianapolis",
+		"America/Jamaica", "America/Kentucky/Louisville", "America/Nassau", "America/New_York", "America/Panama",
+		"America/Toronto", "EST5EDT", "US/Eastern",
+	},
+	"America_Central": {
+		"America/Belize", "America/Chicago", "America/Costa_Rica", "America/El_Salvador", "America/Guatemala",
+		"America/Managua", "America/Mexico_City", "America/Monterrey", "America/Regina", "America/Tegucigalpa",
+		"America/Winnipeg", "CST6CDT", "US/Central",
+	},
+	"America_Mountain": {
+		"America/Boise", "America/Denver", "America/Edmonton", "America/Phoenix", "MST7MDT", "US/Arizona",
+		"US/Mountain",
+	},
+	"America_Pacific": {
+		"America/Los_Angeles", "America/Tijuana", "America/Vancouver", "PST8PDT", "US/Pacific",
+	},
+	"Alaska": {
+		"America/Anchorage", "America/Juneau", "America/Nome", "America/Sitka", "America/Yakutat", "US/Alaska",
+	},
+	"Hawaii_Aleutian": {
+		"America/Adak", "Pacific/Honolulu", "US/Hawaii",
+	},
+	"Atlantic": {
+		"America/Barbados", "America/Halifax", "America/Martinique", "America/Moncton", "America/Puerto_Rico",
+		"America/Santo_Domingo", "Atlantic/Bermuda",
+	},
+	"Brasilia": {
+		"America/Bahia", "America/Belem", "America/Fortaleza", "America/Recife", "America/Sao_Paulo",
+	},
+	"India": {
+		"Asia/Calcutta", "Asia/Kolkata",
+	},
+	"China": {
+		"Asia/Shanghai", "PRC",
+	},
+	"Japan": {
+		"Asia/Tokyo", "Japan",
+	},
+	"Korea": {
+		"Asia/Seoul", "ROK",
+	},
+	"Australia_Eastern": {
+		"Australia/Brisbane", "Australia/Hobart", "Australia/Melbourne", "Australia/Sydney",
+	},
+	"Australia_Central": {
+		"Australia/Adelaide", "Australia/Darwin",
+	},
+	"Australia_Western": {
+		"Australia/Perth",
+	},
+	"New_Zealand": {
+		"Pacific/Auckland", "NZ",
+	},
+}
+
+// metazones maps IANA time zones to their metazone.
+var metazones = make(map[string]string)
+
+func init() {
+	for metazone, zones := range metazoneZones {
+		for _, zone := range zones {
+			metazones[zone] = metazone
+		}
+	}
+}
+
+// zoneNameData contains the CLDR metazone names of the bundled locales, from
+// the CLDR data in golang.org/x/text. Besides metazones, it has entries for
+// the zones whose names differ from their metazone's, e.g. "British Summer
+// Time" for Europe/London, and for Etc/UTC.
+var zoneNameData = map[string]map[string]zoneNames{
+	"de": {
+		"Europe_Central":    {"Mitteleuropäische Normalzeit", "Mitteleuropäische Sommerzeit", "MEZ", "MESZ"},
+		"Europe_Western":    {"Westeuropäische Normalzeit", "Westeuropäische Sommerzeit", "WEZ", "WESZ"},
+		"Europe_Eastern":    {"Osteuropäische Normalzeit", "Osteuropäische Sommerzeit", "OEZ", "OESZ"},
+		"GMT":               {"Mittlere Greenwich-Zeit", "", "", ""},
+		"Moscow":            {"Moskauer Normalzeit", "Moskauer Sommerzeit", "", ""},
+		"America_Eastern":   {"Nordamerikanische Ostküsten-Normalzeit", "Nordamerikanische Ostküsten-Sommerzeit", "", ""},
+		"America_Central":   {"Nordamerikanische Inland-Normalzeit", "Nordamerikanische Inland-Sommerzeit", "", ""},
+		"America_Mountain":  {"Rocky Mountain-Normalzeit", "Rocky-Mountain-Sommerzeit", "", ""},
+		"America_Pacific":   {"Nordamerikanische Westküsten-Normalzeit", "Nordamerikanische Westküsten-Sommerzeit", "", ""},
+		"Alaska":            {"Alaska-Normalzeit", "Alaska-Sommerzeit", "", ""},
+		"Hawaii_Aleutian":   {"Hawaii-Aleuten-Normalzeit", "Hawaii-Aleuten-Sommerzeit", "", ""},
+		"Atlantic":          {"Atlantik-Normalzeit", "Atlantik-Sommerzeit", "", ""},
+		"Brasilia":          {"Brasília-Normalzeit", "Brasília-Sommerzeit", "", ""},
+		"India":             {"Indische Zeit", "", "", ""},
+		"China":             {"Chinesische Normalzeit", "Chinesische Sommerzeit", "", ""},
+		"Japan":             {"Japanische Normalzeit", "Japanische Sommerzeit", "", ""},
+		"Korea":             {"Koreanische Normalzeit", "Koreanische Sommerzeit", "", ""},
+		"Australia_Eastern": {"Ostaustralische Normalzeit", "Ostaustralische Sommerzeit", "", ""},
+		"Australia_Central": {"Zentralaustralische Normalzeit", "Zentralaustralische Sommerzeit", "", ""},
+		"Australia_Western": {"Westaustralische Normalzeit", "Westaustralische Sommerzeit", "", ""},
+		"New_Zealand":       {"Neuseeland-Normalzeit", "Neuseeland-Sommerzeit", "", ""},
+		"Etc/UTC":           {"Koordinierte Weltzeit", "", "UTC", ""},
+		"Europe/London":     {"Mittlere Greenwich-Zeit", "Britische Sommerzeit", "", ""},
+	},
+	"en": {
+		"Europe_Central":    {"Central European Standard Time", "Central European Summer Time", "", ""},
+		"Europe_Western":    {"Western European Standard Time", "Western European Summer Time", "", ""},
+		"Europe_Eastern":    {"Eastern European Standard Time", "Eastern European Summer Time", "", ""},
+		"GMT":               {"Greenwich Mean Time", "", "GMT", ""},
+		"Moscow":            {"Moscow Standard Time", "Moscow Summer Time", "", ""},
+		"America_Eastern":   {"Eastern Standard Time", "Eastern Daylight Time", "EST", "EDT"},
+		"America_Central":   {"Central Standard Time", "Central Daylight Time", "CST", "CDT"},
+		"America_Mountain":  {"Mountain Standard Time", "Mountain Daylight Time", "MST", "MDT"},
+		"America_Pacific":   {"Pacific Standard Time", "Pacific Daylight Time", "PST", "PDT"},
+		"Alaska":            {"Alaska Standard Time", "Alaska Daylight Time", "AKST", "AKDT"},
+		"Hawaii_Aleutian":   {"Hawaii-Aleutian Standard Time", "Hawaii-Aleutian Daylight Time", "HAST", "HADT"},
+		"Atlantic":          {"Atlantic Standard Time", "Atlantic Daylight Time", "AST", "ADT"},
+		"Brasilia":          {"Brasilia Standard Time", "Brasilia Summer Time", "", ""},
+		"India":             {"India Standard Time", "", "", ""},
+		"China":             {"China Standard Time", "China Daylight Time", "", ""},
+		"Japan":             {"Japan Standard Time", "Japan Daylight Time", "", ""},
+		"Korea":             {"Korean Standard Time", "Korean Daylight Time", "", ""},
+		"Australia_Eastern": {"Australian Eastern Standard Time", "Australian Eastern Daylight Time", "", ""},
+		"Australia_Central": {"Australian Central Standard Time", "Australian Central Daylight Time", "", ""},
+		"Australia_Western": {"Australian Western Standard Time", "Australian Western Daylight Time", "", ""},
+		"New_Zealand":       {"New Zealand Standard Time", "New Zealand Daylight Time", "", ""},
+		"Etc/UTC":           {"Coordinated Universal Time", "", "UTC", ""},
+		"Europe/London":     {"Greenwich Mean Time", "British Summer Time", "GMT", ""},
+		"Pacific/Honolulu":  {"Hawaii-Aleutian Standard Time", "Hawaii-Aleutian Daylight Time", "HST", "HDT"},
+	},
+	"en-GB": {
+		"Europe_Central":    {"Central European Standard Time", "Central European Summer Time", "CET", "CEST"},
+		"Europe_Western":    {"Western European Standard Time", "Western European Summer Time", "WET", "WEST"},
+		"Europe_Eastern":    {"Eastern European Standard Time", "Eastern European Summer Time", "EET", "EEST"},
+		"GMT":               {"Greenwich Mean Time", "", "GMT", ""},
+		"Moscow":            {"Moscow Standard Time", "Moscow Summer Time", "", ""},
+		"America_Eastern":   {"Eastern Standard Time", "Eastern Daylight Time", "", ""},
+		"America_Central":   {"Central Standard Time", "Central Daylight Time", "", ""},
+		"America_Mountain":  {"Mountain Standard Time", "Mountain Daylight Time", "", ""},
+		"America_Pacific":   {"Pacific Standard Time", "Pacific Daylight Time", "", ""},
+		"Alaska":            {"Alaska Standard Time", "Alaska Daylight Time", "", ""},
+		"Hawaii_Aleutian":   {"Hawaii-Aleutian Standard Time", "Hawaii-Aleutian Daylight Time", "", ""},
+		"Atlantic":          {"Atlantic Standard Time", "Atlantic Daylight Time", "", ""},
+		"Brasilia":          {"Brasilia Standard Time", "Brasilia Summer Time", "", ""},
+		"India":             {"India Standard Time", "", "", ""},
+		"China":             {"China Standard Time", "China Daylight Time", "", ""},
+		"Japan":             {"Japan Standard Time", "Japan Daylight Time", "", ""},
+		"Korea":             {"Korean Standard Time", "Korean Daylight Time", "", ""},
+		"Australia_Eastern": {"Australian Eastern Standard Time", "Australian Eastern Daylight Time", "", ""},
+		"Australia_Central": {"Australian Central Standard Time", "Australian Central Daylight Time", "", ""},
+		"Australia_Western": {"Australian Western Standard Time", "Australian Western Daylight Time", "", ""},
+		"New_Zealand":       {"New Zealand Standard Time", "New Zealand Daylight Time", "", ""},
+		"Etc/UTC":           {"Coordinated Universal Time", "", "UTC", ""},
+		"Europe/London":     {"Greenwich Mean Time", "British Summer Time", "GMT", "BST"},
+	},
+	"es": {
+		"Europe_Central":    {"hora estándar de Europa central", "hora de verano de Europa central", "CET", "CEST"},
+		"Europe_Western":    {"hora estándar de Europa occidental", "hora de verano de Europa occidental", "WET", "WEST"},
+		"Europe_Eastern":    {"hora estándar de Europa oriental", "hora de verano de Europa oriental", "EET", "EEST"},
+		"GMT":               {"hora del meridiano de Greenwich", "", "GMT", ""},
+		"Moscow":            {"hora estándar de Moscú", "hora de verano de Moscú", "", ""},
+		"America_Eastern":   {"hora estándar oriental", "hora de verano oriental", "", ""},
+		"America_Central":   {"hora estándar central", "hora de verano central", "", ""},
+		"America_Mountain":  {"hora estándar de las Montañas Rocosas", "hora de verano de las Montañas Rocosas", "", ""},
+		"America_Pacific":   {"hora estándar del Pacífico", "hora de verano del Pacífico", "", ""},
+		"Alaska":            {"hora estándar de Alaska", "hora de verano de Alaska", "", ""},
+		"Hawaii_Aleutian":   {"hora estándar de Hawái-Aleutianas", "hora de verano de Hawái-Aleutianas", "", ""},
+		"Atlantic":          {"hora estándar del Atlántico", "hora de verano del Atlántico", "", ""},
+		"Brasilia":          {"hora estándar de Brasilia", "hora de verano de Brasilia", "", ""},
+		"India":             {"hora estándar de la India", "", "", ""},
+		"China":             {"hora estándar de China", "hora de verano de China", "", ""},
+		"Japan":             {"hora estándar de Japón", "hora de verano de Japón", "", ""},
+		"Korea":             {"hora estándar de Corea", "hora de verano de Corea", "", ""},
+		"Australia_Eastern": {"hora estándar de Australia oriental", "hora de verano de Australia oriental", "", ""},
+		"Australia_Central": {"hora estándar de Australia central", "hora de verano de Australia central", "", ""},
+		"Australia_Western": {"hora estándar de Australia occidental", "hora de verano de Australia occidental", "", ""},
+		"New_Zealand":       {"hora estándar de Nueva Zelanda", "hora de verano de Nueva Zelanda", "", ""},
+		"Etc/UTC":           {"tiempo universal coordinado", "", "UTC", ""},
+		"Europe/London":     {"hora del meridiano de Greenwich", "hora de verano británica", "GMT", ""},
+	},
+	"fr": {
+		"Europe_Central":    {"heure normale d’Europe centrale", "heure d’été d’Europe centrale", "", ""},
+		"Europe_Western":    {"heure normale d’Europe de l’Ouest", "heure d’été d’Europe de l’Ouest", "", ""},
+		"Europe_Eastern":    {"heure normale d’Europe de l’Est", "heure d’été d’Europe de l’Est", "", ""},
+		"GMT":               {"heure moyenne de Greenwich", "", "", ""},
+		"Moscow":            {"heure normale de Moscou", "heure d’été de Moscou", "", ""},
+		"America_Eastern":   {"heure normale de l’Est nord-américain", "heure d’été de l’Est", "HNE", "HEE"},
+		"America_Central":   {"heure normale du centre nord-américain", "heure d’été du Centre", "HNC", "HEC"},
+		"America_Mountain":  {"heure normale des Rocheuses", "heure d’été des Rocheuses", "HNR", "HER"},
+		"America_Pacific":   {"heure normale du Pacifique nord-américain", "heure d’été du Pacifique", "HNP", "HEP"},
+		"Alaska":            {"heure normale de l’Alaska", "heure d’été de l’Alaska", "HNAK", "HEAK"},
+		"Hawaii_Aleutian":   {"heure normale d’Hawaii - Aléoutiennes", "heure d’été d’Hawaii - Aléoutiennes", "HNHA", "HEHA"},
+		"Atlantic":          {"heure normale de l’Atlantique", "heure d’été de l’Atlantique", "HNA", "HEA"},
+		"Brasilia":          {"heure normale de Brasilia", "heure d’été de Brasilia", "", ""},
+		"India":             {"heure de l’Inde", "", "", ""},
+		"China":             {"heure normale de la Chine", "heure d’été de Chine", "", ""},
+		"Japan":             {"heure normale du Japon", "heure d’été du Japon", "", ""},
+		"Korea":             {"heure normale de la Corée", "heure d’été de Corée", "", ""},
+		"Australia_Eastern": {"heure normale de l’Est de l’Australie", "heure d’été de l’Est de l’Australie", "", ""},
+		"Australia_Central": {"heure normale du centre de l’Australie", "heure d’été du centre de l’Australie", "", ""},
+		"Australia_Western": {"heure normale de l’Ouest de l’Australie", "heure d’été de l’Ouest de l’Australie", "", ""},
+		"New_Zealand":       {"heure normale de la Nouvelle-Zélande", "heure d’été de la Nouvelle-Zélande", "", ""},
+		"Etc/UTC":           {"Temps universel coordonné", "", "UTC", ""},
+		"Europe/London":     {"heure moyenne de Greenwich", "heure d’été britannique", "", ""},
+	},
+	"it": {
+		"Europe_Central":    {"Ora standard dell’Europa centrale", "Ora legale dell’Europa centrale", "CET", "CEST"},
+		"Europe_Western":    {"Ora standard dell’Europa occidentale", "Ora legale dell’Europa occidentale", "WET", "WEST"},
+		"Europe_Eastern":    {"Ora standard dell’Europa orientale", "Ora legale dell’Europa orientale", "EET", "EEST"},
+		"GMT":               {"Ora del meridiano di Greenwich", "", "", ""},
+		"Moscow":            {"Ora standard di Mosca", "Ora legale di Mosca", "", ""},
+		"America_Eastern":   {"Ora standard orientale USA", "Ora legale orientale USA", "", ""},
+		"America_Central":   {"Ora standard centrale USA", "Ora legale centrale USA", "", ""},
+		"America_Mountain":  {"Ora standard Montagne Rocciose USA", "Ora legale Montagne Rocciose USA", "", ""},
+		"America_Pacific":   {"Ora standard del Pacifico USA", "Ora legale del Pacifico USA", "", ""},
+		"Alaska":            {"Ora standard dell’Alaska", "Ora legale dell’Alaska", "", ""},
+		"Hawaii_Aleutian":   {"Ora standard delle Isole Hawaii-Aleutine", "Ora legale delle Isole Hawaii-Aleutine", "", ""},
+		"Atlantic":          {"Ora standard dell’Atlantico", "Ora legale dell’Atlantico", "", ""},
+		"Brasilia":          {"Ora standard di Brasilia", "Ora legale di Brasilia", "", ""},
+		"India":             {"Ora standard dell’India", "", "", ""},
+		"China":             {"Ora standard della Cina", "Ora legale della Cina", "", ""},
+		"Japan":             {"Ora standard del Giappone", "Ora legale del Giappone", "", ""},
+		"Korea":             {"Ora standard coreana", "Ora legale coreana", "", ""},
+		"Australia_Eastern": {"Ora standard dell’Australia orientale", "Ora legale dell’Australia orientale", "", ""},
+		"Australia_Central": {"Ora standard dell’Australia centrale", "Ora legale dell’Australia centrale", "", ""},
+		"Australia_Western": {"Ora standard dell’Australia occidentale", "Ora legale dell’Australia occidentale", "", ""},
+		"New_Zealand":       {"Ora standard della Nuova Zelanda", "Ora legale della Nuova Zelanda", "", ""},
+		"Etc/UTC":           {"Tempo coordinato universale", "", "UTC", ""},
+		"Europe/London":     {"Ora del meridiano di Greenwich", "Ora legale del Regno Unito", "", ""},
+	},
+	"nl": {
+		"Europe_Central":    {"Midden-Europese standaardtijd", "Midden-Europese zomertijd", "CET", "CEST"},
+		"Europe_Western":    {"West-Europese standaardtijd", "West-Europese zomertijd", "WET", "WEST"},
+		"Europe_Eastern":    {"Oost-Europese standaardtijd", "Oost-Europese zomertijd", "EET", "EEST"},
+		"GMT":               {"Greenwich Mean Time", "", "", ""},
+		"Moscow":            {"Moskou-standaardtijd", "Moskou-zomertijd", "", ""},
+		"America_Eastern":   {"Eastern-standaardtijd", "Eastern-zomertijd", "", ""},
+		"America_Central":   {"Central-standaardtijd", "Central-zomertijd", "", ""},
+		"America_Mountain":  {"Mountain-standaardtijd", "Mountain-zomertijd", "", ""},
+		"America_Pacific":   {"Pacific-standaardtijd", "Pacific-zomertijd", "", ""},
+		"Alaska":            {"Alaska-standaardtijd", "Alaska-zomertijd", "", ""},
+		"Hawaii_Aleutian":   {"Hawaii-Aleoetische standaardtijd", "Hawaii-Aleoetische zomertijd", "", ""},
+		"Atlantic":          {"Atlantic-standaardtijd", "Atlantic-zomertijd", "", ""},
+		"Brasilia":          {"Braziliaanse standaardtijd", "Braziliaanse zomertijd", "", ""},
+		"India":             {"Indiase tijd", "", "", ""},
+		"China":             {"Chinese standaardtijd", "Chinese zomertijd", "", ""},
+		"Japan":             {"Japanse standaardtijd", "Japanse zomertijd", "", ""},
+		"Korea":             {"Koreaanse standaardtijd", "Koreaanse zomertijd", "", ""},
+		"Australia_Eastern": {"Oost-Australische standaardtijd", "Oost-Australische zomertijd", "", ""},
+		"Australia_Central": {"Midden-Australische standaardtijd", "Midden-Australische zomertijd", "", ""},
+		"Australia_Western": {"West-Australische standaardtijd", "West-Australische zomertijd", "", ""},
+		"New_Zealand":       {"Nieuw-Zeelandse standaardtijd", "Nieuw-Zeelandse zomertijd", "", ""},
+		"Etc/UTC":           {"Gecoördineerde wereldtijd", "", "UTC", ""},
+		"Europe/London":     {"Greenwich Mean Time", "Britse zomertijd", "", ""},
+		"Pacific/Honolulu":  {"Hawaii-Aleoetische standaardtijd", "Hawaii-Aleoetische zomertijd", "HST", "HDT"},
+	},
+	"pt": {
+		"Europe_Central":    {"Horário Padrão da Europa Central", "Horário de Verão da Europa Central", "", ""},
+		"Europe_Western":    {"Horário Padrão da Europa Ocidental", "Horário de Verão da Europa Ocidental", "", ""},
+		"Europe_Eastern":    {"Horário Padrão da Europa Oriental", "Horário de Verão da Europa Oriental", "", ""},
+		"GMT":               {"Horário do Meridiano de Greenwich", "", "", ""},
+		"Moscow":            {"Horário Padrão de Moscou", "Horário de Verão de Moscou", "", ""},
+		"America_Eastern":   {"Horário Padrão do Leste", "Horário de Verão do Leste", "", ""},
+		"America_Central":   {"Horário Padrão Central", "Horário de Verão Central", "", ""},
+		"America_Mountain":  {"Horário Padrão das Montanhas", "Horário de Verão das Montanhas", "", ""},
+		"America_Pacific":   {"Horário Padrão do Pacífico", "Horário de Verão do Pacífico", "", ""},
+		"Alaska":            {"Horário Padrão do Alasca", "Horário de Verão do Alasca", "", ""},
+		"Hawaii_Aleutian":   {"Horário Padrão do Havaí e Ilhas Aleutas", "Horário de Verão do Havaí e Ilhas Aleutas", "", ""},
+		"Atlantic":          {"Horário Padrão do Atlântico", "Horário de Verão do Atlântico", "", ""},
+		"Brasilia":          {"Horário Padrão de Brasília", "Horário de Verão de Brasília", "BRT", "BRST"},
+		"India":             {"Horário Padrão da Índia", "", "", ""},
+		"China":             {"Horário Padrão da China", "Horário de Verão da China", "", ""},
+		"Japan":             {"Horário Padrão do Japão", "Horário de Verão do Japão", "", ""},
+		"Korea":             {"Horário Padrão da Coreia", "Horário de Verão da Coreia", "", ""},
+		"Australia_Eastern": {"Horário Padrão da Austrália Oriental", "Horário de Verão da Austrália Oriental", "", ""},
+		"Australia_Central": {"Horário Padrão da Austrália Central", "Horário de Verão da Austrália Central", "", ""},
+		"Australia_Western": {"Horário Padrão da Austrália Ocidental", "Horário de Verão da Austrália Ocidental", "", ""},
+		"New_Zealand":       {"Horário Padrão da Nova Zelândia", "Horário de Verão da Nova Zelândia", "", ""},
+		"Etc/UTC":           {"Horário Universal Coordenado", "", "UTC", ""},
+		"Europe/London":     {"Horário do Meridiano de Greenwich", "Horário de Verão Britânico", "", ""},
+	},
+}
+
+// zoneName returns the CLDR name of the time zone of t: the long specific
+// name such as "Mitteleuropäische Normalzeit" if long is true, and the short
+// one such as "MEZ" otherwise. If the locale has no such name, it returns the
+// localized GMT format, e.g. "GMT+01:00" or, if short, "GMT+1".
+func zoneName(t time.Time, cal *calendarData, sym *numberSymbols, long bool) string {
+	zone := t.Location().String()
+	if zone == "UTC" {
+		zone = "Etc/UTC"
+	}
+	names, ok := cal.zones[zone]
+	if !ok {
+		names = cal.zones[metazones[zone]]
+	}
+	idx := 0
+	if !long {
+		idx = 2
+	}
+	if t.IsDST() {
+		idx++
+	}
+	if names[idx] != "" {
+		return names[idx]
+	}
+	_, offset := t.Zone()
+	return cal.gmtName(offset, sym, !long)
+}
+
+// gmtName formats offset in the localized GMT format of cal, e.g. "GMT+01:00"
+// or, if short, "GMT+1". A zero offset is shown as e.g. "GMT".
+func (cal *calendarData) gmtName(offset int, sym *numberSymbols, short bool) string {
+	if offset == 0 {
+		return cal.gmtZeroFormat
+	}
+	pattern, negative, _ := strings.Cut(cal.hourFormat, ";")
+	if offset < 0 {
+		pattern, offset = negative, -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	if short {
+		pattern = strings.Replace(pattern, "HH", "H", 1)
+		if idx := strings.Index(pattern, "mm"); idx > 0 && minutes == 0 {
+			// Drop the minutes and the separator before them
+			pattern = pattern[:idx-1] + pattern[idx+2:]
+		}
+	}
+
+	var sb strings.Builder
+	for idx := 0; idx < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[idx:], "HH"):
+			sb.WriteString(sym.digitString(hours, 2))
+			idx += 2
+		case pattern[idx] == 'H':
+			sb.WriteString(sym.digitString(hours, 1))
+			idx++
+		case strings.HasPrefix(pattern[idx:], "mm"):
+			sb.WriteString(sym.digitString(minutes, 2))
+			idx += 2
+		default:
+			sb.WriteByte(pattern[idx])
+			idx++
+		}
+	}
+	return strings.Replace(cal.gmtFormat, "{0}", sb.String(), 1)
+}
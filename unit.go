@@ -0,0 +1,408 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/feature/plural"
+)
+
+// Unit widths as defined by CLDR.
+const (
+	widthLong   = "long"
+	widthShort  = "short"
+	widthNarrow = "narrow"
+)
+
+// Pseudo-units that pick the unit from the value.
+const (
+	unitBytes    = "bytes"
+	unitDuration = "duration"
+)
+
+// unitSymbols are the short symbols shared by most locales.
+var unitSymbols = map[string]string{
+	"byte": "B", "kilobyte": "kB", "megabyte": "MB", "gigabyte": "GB", "terabyte": "TB", "petabyte": "PB",
+	"kibibyte": "KiB", "mebibyte": "MiB", "gibibyte": "GiB", "tebibyte": "TiB", "pebibyte": "PiB",
+	"millisecond": "ms", "second": "s", "minute": "min", "hour": "h",
+	"millimeter": "mm", "centimeter": "cm", "meter": "m", "kilometer": "km",
+	"inch": "in", "foot": "ft", "mile": "mi",
+	"milligram": "mg", "gram": "g", "kilogram": "kg", "tonne": "t", "ounce": "oz", "pound": "lb",
+}
+
+// unitSpec holds the patterns of a unit in the long, short and narrow widths.
+// A pattern is either one pattern for all plural categories, or the patterns
+// of the categories one and other separated by "|", e.g.
+// "{0} Jahr|{0} Jahre". An empty short pattern is the symbol of the unit in
+// unitSymbols, an empty narrow pattern the short one.
+type unitSpec struct {
+	long, short, narrow string
+}
+
+// unitLocale holds the unit patterns of a locale.
+type unitLocale struct {
+	// narrowSpace keeps the space between number and unit in the narrow
+	// patterns derived from the short ones, e.g. "5 km" instead of "5km".
+	narrowSpace bool
+
+	units map[string]unitSpec
+}
+
+// unitData contains the CLDR unit patterns of the bundled locales.
+var unitData = map[string]*unitLocale{
+	"de": {narrowSpace: true, units: map[string]unitSpec{
+		"byte": {"{0} Byte", "{0} Byte", "{0} B"}, "kilobyte": {long: "{0} Kilobyte"}, "megabyte": {long: "{0} Megabyte"},
+		"gigabyte": {long: "{0} Gigabyte"}, "terabyte": {long: "{0} Terabyte"}, "petabyte": {long: "{0} Petabyte"},
+		"kibibyte": {long: "{0} Kibibyte"}, "mebibyte": {long: "{0} Mebibyte"}, "gibibyte": {long: "{0} Gibibyte"},
+		"tebibyte": {long: "{0} Tebibyte"}, "pebibyte": {long: "{0} Pebibyte"},
+		"millisecond": {long: "{0} Millisekunde|{0} Millisekunden"},
+		"second":      {"{0} Sekunde|{0} Sekunden", "{0} Sek.", "{0} s"},
+		"minute":      {"{0} Minute|{0} Minuten", "{0} Min.", ""},
+		"hour":        {"{0} Stunde|{0} Stunden", "{0} Std.", ""},
+		"day":         {"{0} Tag|{0} Tage", "{0} Tg.", "{0} T."},
+		"week":        {"{0} Woche|{0} Wochen", "{0} Wo.", "{0} W."},
+		"month":       {"{0} Monat|{0} Monate", "{0} Mon.", "{0} M."},
+		"year":        {"{0} Jahr|{0} Jahre", "{0} J.", ""},
+		"millimeter":  {long: "{0} Millimeter"}, "centimeter": {long: "{0} Zentimeter"},
+		"meter": {long: "{0} Meter"}, "kilometer": {long: "{0} Kilometer"},
+		"inch": {"{0} Zoll", "{0} Zoll", ""}, "foot": {long: "{0} Fuß"}, "mile": {long: "{0} Meile|{0} Meilen"},
+		"milligram": {long: "{0} Milligramm"}, "gram": {long: "{0} Gramm"}, "kilogram": {long: "{0} Kilogramm"},
+		"tonne": {long: "{0} Tonne|{0} Tonnen"}, "ounce": {long: "{0} Unze|{0} Unzen"}, "pound": {long: "{0} Pfund"},
+	}},
+	"en": {units: map[string]unitSpec{
+		"byte": {"{0} byte|{0} bytes", "{0} byte", "{0}B"}, "kilobyte": {long: "{0} kilobyte|{0} kilobytes"},
+		"megabyte": {long: "{0} megabyte|{0} megabytes"}, "gigabyte": {long: "{0} gigabyte|{0} gigabytes"},
+		"terabyte": {long: "{0} terabyte|{0} terabytes"}, "petabyte": {long: "{0} petabyte|{0} petabytes"},
+		"kibibyte": {long: "{0} kibibyte|{0} kibibytes"}, "mebibyte": {long: "{0} mebibyte|{0} mebibytes"},
+		"gibibyte": {long: "{0} gibibyte|{0} gibibytes"}, "tebibyte": {long: "{0} tebibyte|{0} tebibytes"},
+		"pebibyte":    {long: "{0} pebibyte|{0} pebibytes"},
+		"millisecond": {long: "{0} millisecond|{0} milliseconds"},
+		"second":      {"{0} second|{0} seconds", "{0} sec", "{0}s"},
+		"minute":      {"{0} minute|{0} minutes", "{0} min", "{0}m"},
+		"hour":        {"{0} hour|{0} hours", "{0} hr", "{0}h"},
+		"day":         {"{0} day|{0} days", "{0} day|{0} days", "{0}d"},
+		"week":        {"{0} week|{0} weeks", "{0} wk|{0} wks", "{0}w"},
+		"month":       {"{0} month|{0} months", "{0} mth|{0} mths", "{0}m"},
+		"year":        {"{0} year|{0} years", "{0} yr|{0} yrs", "{0}y"},
+		"millimeter":  {long: "{0} millimeter|{0} millimeters"}, "centimeter": {long: "{0} centimeter|{0} centimeters"},
+		"meter": {long: "{0} meter|{0} meters"}, "kilometer": {long: "{0} kilometer|{0} kilometers"},
+		"inch": {"{0} inch|{0} inches", "", "{0}″"}, "foot": {"{0} foot|{0} feet", "", "{0}′"},
+		"mile": {long: "{0} mile|{0} miles"}, "milligram": {long: "{0} milligram|{0} milligrams"},
+		"gram": {long: "{0} gram|{0} grams"}, "kilogram": {long: "{0} kilogram|{0} kilograms"},
+		"tonne": {long: "{0} metric ton|{0} metric tons"}, "ounce": {long: "{0} ounce|{0} ounces"},
+		"pound": {long: "{0} pound|{0} pounds"},
+	}},
+	"es": {units: map[string]unitSpec{
+		"byte": {long: "{0} byte|{0} bytes"}, "kilobyte": {long: "{0} kilobyte|{0} kilobytes"},
+		"megabyte": {long: "{0} megabyte|{0} megabytes"}, "gigabyte": {long: "{0} gigabyte|{0} gigabytes"},
+		"terabyte": {long: "{0} terabyte|{0} terabytes"}, "petabyte": {long: "{0} petabyte|{0} petabytes"},
+		"kibibyte": {long: "{0} kibibyte|{0} kibibytes"}, "mebibyte": {long: "{0} mebibyte|{0} mebibytes"},
+		"gibibyte": {long: "{0} gibibyte|{0} gibibytes"}, "tebibyte": {long: "{0} tebibyte|{0} tebibytes"},
+		"pebibyte":    {long: "{0} pebibyte|{0} pebibytes"},
+		"millisecond": {long: "{0} milisegundo|{0} milisegundos"},
+		"second":      {long: "{0} segundo|{0} segundos"},
+		"minute":      {long: "{0} minuto|{0} minutos"},
+		"hour":        {long: "{0} hora|{0} horas"},
+		"day":         {"{0} día|{0} días", "{0} d", ""},
+		"week":        {"{0} semana|{0} semanas", "{0} sem.", ""},
+		"month":       {"{0} mes|{0} meses", "{0} m.", ""},
+		"year":        {"{0} año|{0} años", "{0} a", ""},
+		"millimeter":  {long: "{0} milímetro|{0} milímetros"}, "centimeter": {long: "{0} centímetro|{0} centímetros"},
+		"meter": {long: "{0} metro|{0} metros"}, "kilometer": {long: "{0} kilómetro|{0} kilómetros"},
+		"inch": {long: "{0} pulgada|{0} pulgadas"}, "foot": {long: "{0} pie|{0} pies"}, "mile": {long: "{0} milla|{0} millas"},
+		"milligram": {long: "{0} miligramo|{0} miligramos"}, "gram": {long: "{0} gramo|{0} gramos"},
+		"kilogram": {long: "{0} kilogramo|{0} kilogramos"}, "tonne": {long: "{0} tonelada|{0} toneladas"},
+		"ounce": {long: "{0} onza|{0} onzas"}, "pound": {long: "{0} libra|{0} libras"},
+	}},
+	"fr": {units: map[string]unitSpec{
+		"byte": {"{0} octet|{0} octets", "{0} o", ""}, "kilobyte": {"{0} kilooctet|{0} kilooctets", "{0} ko", ""},
+		"megabyte": {"{0} mégaoctet|{0} mégaoctets", "{0} Mo", ""}, "gigabyte": {"{0} gigaoctet|{0} gigaoctets", "{0} Go", ""},
+		"terabyte": {"{0} téraoctet|{0} téraoctets", "{0} To", ""}, "petabyte": {"{0} pétaoctet|{0} pétaoctets", "{0} Po", ""},
+		"kibibyte": {"{0} kibioctet|{0} kibioctets", "{0} Kio", ""}, "mebibyte": {"{0} mébioctet|{0} mébioctets", "{0} Mio", ""},
+		"gibibyte": {"{0} gibioctet|{0} gibioctets", "{0} Gio", ""}, "tebibyte": {"{0} tébioctet|{0} tébioctets", "{0} Tio", ""},
+		"pebibyte":    {"{0} pébioctet|{0} pébioctets", "{0} Pio", ""},
+		"millisecond": {long: "{0} milliseconde|{0} millisecondes"},
+		"second":      {long: "{0} seconde|{0} secondes"},
+		"minute":      {long: "{0} minute|{0} minutes"},
+		"hour":        {long: "{0} heure|{0} heures"},
+		"day":         {"{0} jour|{0} jours", "{0} j", ""},
+		"week":        {"{0} semaine|{0} semaines", "{0} sem.", ""},
+		"month":       {"{0} mois", "{0} m.", ""},
+		"year":        {"{0} an|{0} ans", "{0} a", ""},
+		"millimeter":  {long: "{0} millimètre|{0} millimètres"}, "centimeter": {long: "{0} centimètre|{0} centimètres"},
+		"meter": {long: "{0} mètre|{0} mètres"}, "kilometer": {long: "{0} kilomètre|{0} kilomètres"},
+		"inch": {"{0} pouce|{0} pouces", "{0} po", ""}, "foot": {"{0} pied|{0} pieds", "{0} pi", ""},
+		"mile": {long: "{0} mille|{0} milles"}, "milligram": {long: "{0} milligramme|{0} milligrammes"},
+		"gram": {long: "{0} gramme|{0} grammes"}, "kilogram": {long: "{0} kilogramme|{0} kilogrammes"},
+		"tonne": {long: "{0} tonne|{0} tonnes"}, "ounce": {long: "{0} once|{0} onces"}, "pound": {long: "{0} livre|{0} livres"},
+	}},
+	"it": {units: map[string]unitSpec{
+		"byte": {"{0} byte", "{0} byte", ""}, "kilobyte": {long: "{0} kilobyte"}, "megabyte": {long: "{0} megabyte"},
+		"gigabyte": {long: "{0} gigabyte"}, "terabyte": {long: "{0} terabyte"}, "petabyte": {long: "{0} petabyte"},
+		"kibibyte": {long: "{0} kibibyte"}, "mebibyte": {long: "{0} mebibyte"}, "gibibyte": {long: "{0} gibibyte"},
+		"tebibyte": {long: "{0} tebibyte"}, "pebibyte": {long: "{0} pebibyte"},
+		"millisecond": {long: "{0} millisecondo|{0} millisecondi"},
+		"second":      {long: "{0} secondo|{0} secondi"},
+		"minute":      {long: "{0} minuto|{0} minuti"},
+		"hour":        {long: "{0} ora|{0} ore"},
+		"day":         {"{0} giorno|{0} giorni", "{0} g", ""},
+		"week":        {"{0} settimana|{0} settimane", "{0} sett.", ""},
+		"month":       {"{0} mese|{0} mesi", "{0} mese|{0} mesi", "{0} m"},
+		"year":        {"{0} anno|{0} anni", "{0} anno|{0} anni", "{0} a"},
+		"millimeter":  {long: "{0} millimetro|{0} millimetri"}, "centimeter": {long: "{0} centimetro|{0} centimetri"},
+		"meter": {long: "{0} metro|{0} metri"}, "kilometer": {long: "{0} chilometro|{0} chilometri"},
+		"inch": {long: "{0} pollice|{0} pollici"}, "foot": {long: "{0} piede|{0} piedi"}, "mile": {long: "{0} miglio|{0} miglia"},
+		"milligram": {long: "{0} milligrammo|{0} milligrammi"}, "gram": {long: "{0} grammo|{0} grammi"},
+		"kilogram": {long: "{0} chilogrammo|{0} chilogrammi"}, "tonne": {long: "{0} tonnellata|{0} tonnellate"},
+		"ounce": {long: "{0} oncia|{0} once"}, "pound": {long: "{0} libbra|{0} libbre"},
+	}},
+	"nl": {units: map[string]unitSpec{
+		"byte": {"{0} byte", "{0} byte", ""}, "kilobyte": {long: "{0} kilobyte"}, "megabyte": {long: "{0} megabyte"},
+		"gigabyte": {long: "{0} gigabyte"}, "terabyte": {long: "{0} terabyte"}, "petabyte": {long: "{0} petabyte"},
+		"kibibyte": {long: "{0} kibibyte"}, "mebibyte": {long: "{0} mebibyte"}, "gibibyte": {long: "{0} gibibyte"},
+		"tebibyte": {long: "{0} tebibyte"}, "pebibyte": {long: "{0} pebibyte"},
+		"millisecond": {long: "{0} milliseconde|{0} milliseconden"},
+		"second":      {"{0} seconde|{0} seconden", "{0} sec", "{0}s"},
+		"minute":      {long: "{0} minuut|{0} minuten"},
+		"hour":        {"{0} uur", "{0} uur", "{0}u"},
+		"day":         {"{0} dag|{0} dagen", "{0} dag|{0} dagen", "{0}d"},
+		"week":        {"{0} week|{0} weken", "{0} wk|{0} wkn", "{0}w"},
+		"month":       {"{0} maand|{0} maanden", "{0} mnd", "{0}m"},
+		"year":        {"{0} jaar", "{0} jr", "{0}j"},
+		"millimeter":  {long: "{0} millimeter"}, "centimeter": {long: "{0} centimeter"},
+		"meter": {long: "{0} meter"}, "kilometer": {long: "{0} kilometer"},
+		"inch": {"{0} inch", "{0} inch", ""}, "foot": {long: "{0} voet"}, "mile": {long: "{0} mijl"},
+		"milligram": {long: "{0} milligram"}, "gram": {long: "{0} gram"}, "kilogram": {long: "{0} kilogram"},
+		"tonne": {long: "{0} ton"}, "ounce": {long: "{0} ounce"}, "pound": {long: "{0} pond"},
+	}},
+	"pt": {units: map[string]unitSpec{
+		"byte": {"{0} byte|{0} bytes", "{0} byte", ""}, "kilobyte": {long: "{0} kilobyte|{0} kilobytes"},
+		"megabyte": {long: "{0} megabyte|{0} megabytes"}, "gigabyte": {long: "{0} gigabyte|{0} gigabytes"},
+		"terabyte": {long: "{0} terabyte|{0} terabytes"}, "petabyte": {long: "{0} petabyte|{0} petabytes"},
+		"kibibyte": {long: "{0} kibibyte|{0} kibibytes"}, "mebibyte": {long: "{0} mebibyte|{0} mebibytes"},
+		"gibibyte": {long: "{0} gibibyte|{0} gibibytes"}, "tebibyte": {long: "{0} tebibyte|{0} tebibytes"},
+		"pebibyte":    {long: "{0} pebibyte|{0} pebibytes"},
+		"millisecond": {long: "{0} milissegundo|{0} milissegundos"},
+		"second":      {"{0} segundo|{0} segundos", "{0} seg", "{0}s"},
+		"minute":      {long: "{0} minuto|{0} minutos"},
+		"hour":        {long: "{0} hora|{0} horas"},
+		"day":         {"{0} dia|{0} dias", "{0} dia|{0} dias", "{0}d"},
+		"week":        {"{0} semana|{0} semanas", "{0} sem.", ""},
+		"month":       {"{0} mês|{0} meses", "{0} mês|{0} meses", "{0}m"},
+		"year":        {"{0} ano|{0} anos", "{0} ano|{0} anos", "{0}a"},
+		"millimeter":  {long: "{0} milímetro|{0} milímetros"}, "centimeter": {long: "{0} centímetro|{0} centímetros"},
+		"meter": {long: "{0} metro|{0} metros"}, "kilometer": {long: "{0} quilômetro|{0} quilômetros"},
+		"inch": {long: "{0} polegada|{0} polegadas"}, "foot": {long: "{0} pé|{0} pés"}, "mile": {long: "{0} milha|{0} milhas"},
+		"milligram": {long: "{0} miligrama|{0} miligramas"}, "gram": {long: "{0} grama|{0} gramas"},
+		"kilogram": {long: "{0} quilograma|{0} quilogramas"}, "tonne": {long: "{0} tonelada|{0} toneladas"},
+		"ounce": {long: "{0} onça|{0} onças"}, "pound": {long: "{0} libra|{0} libras"},
+	}},
+}
+
+// byteUnits lists the units of the byte size systems from the smallest to
+// the largest, with the factor between them.
+var byteUnits = map[string]struct {
+	base  int64
+	units []string
+}{
+	"iec": {1024, []string{"byte", "kibibyte", "mebibyte", "gibibyte", "tebibyte", "pebibyte"}},
+	"si":  {1000, []string{"byte", "kilobyte", "megabyte", "gigabyte", "terabyte", "petabyte"}},
+}
+
+// durationUnits lists the units of durations from the largest to the
+// smallest, as used by the duration pseudo-unit.
+var durationUnits = []relativeUnit{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// unitForms returns the patterns of unit in the given width for the plural
+// categories.
+func (loc *unitLocale) unitForms(unit, width string) pluralForms {
+	spec, ok := loc.units[unit]
+	if !ok {
+		spec = unitData["en"].units[unit]
+	}
+	short := spec.short
+	if short == "" {
+		short = "{0} " + unitSymbols[unit]
+	}
+	pattern := short
+	switch width {
+	case widthLong:
+		pattern = spec.long
+	case widthNarrow:
+		pattern = spec.narrow
+		if pattern == "" {
+			pattern = short
+			if !loc.narrowSpace {
+				pattern = strings.Replace(pattern, "{0} ", "{0}", 1)
+			}
+		}
+	}
+	one, other, ok := strings.Cut(pattern, "|")
+	if !ok {
+		return pluralForms{"other": pattern}
+	}
+	return pluralForms{"one": one, "other": other}
+}
+
+// unitOptions controls how a measurement is formatted.
+type unitOptions struct {
+	width  string
+	system string
+	number string
+}
+
+// parseUnitOptions splits space-separated unit options: a width (long, short
+// or narrow; default short), the byte size system (iec or si; default iec)
+// and number options as in i18nNumber.
+func parseUnitOptions(opts string) unitOptions {
+	result := unitOptions{width: widthShort, system: "iec"}
+	var numberOpts []string
+	for _, opt := range strings.Fields(opts) {
+		switch opt {
+		case widthLong, widthShort, widthNarrow:
+			result.width = opt
+		case "iec", "si":
+			result.system = opt
+		default:
+			numberOpts = append(numberOpts, opt)
+		}
+	}
+	result.number = strings.Join(numberOpts, " ")
+	return result
+}
+
+// formatUnit formats value as a measurement of unit in lang, e.g. "5 km" or
+// "5 Kilometer". unit is one of the units of unitData, such as kilometer or
+// hour, or one of the pseudo-units bytes, which picks the largest byte unit
+// (KiB, MiB, ... or kB, MB, ... with si) that keeps the number at 1 or
+// above, and duration, which splits a duration into days, hours, minutes and
+// seconds.
+func formatUnit(value interface{}, unit, lang, opts string) (string, error) {
+	options := parseUnitOptions(opts)
+	if _, ok := findLocale(unitData, lang); !ok {
+		// A locale without bundled unit patterns is formatted entirely
+		// in English, so the plural category, the digits and the list
+		// pattern match the English unit names.
+		lang = "en"
+	}
+	if unit == unitDuration {
+		return formatDurationUnits(value, lang, options)
+	}
+
+	d, err := toDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	defaults := defaultNumberOptions
+	if unit == unitBytes {
+		defaults.maxFraction = 1
+	} else if _, ok := unitData["en"].units[unit]; !ok {
+		return "", fmt.Errorf("unknown unit %q", unit)
+	}
+	numberOpts, err := parseNumberOptions(options.number, defaults)
+	if err != nil {
+		return "", err
+	}
+	if unit == unitBytes {
+		d, unit = scaleBytes(d, options.system, numberOpts)
+	}
+	return formatMeasure(d, unit, lang, options.width, numberOpts), nil
+}
+
+// scaleBytes converts a number of bytes to the largest unit of system in
+// which it is still at least 1 after rounding to opts.
+func scaleBytes(d decimal.Decimal, system string, opts numberOptions) (decimal.Decimal, string) {
+	sizes := byteUnits[system]
+	base := decimal.NewFromInt(sizes.base)
+	idx := 0
+	for idx < len(sizes.units)-1 && roundDecimal(d.Abs(), opts.maxFraction, opts.rounding).GreaterThanOrEqual(base) {
+		d = d.Div(base)
+		idx++
+	}
+	return d, sizes.units[idx]
+}
+
+// formatMeasure formats d with the pattern of unit in lang and width for the
+// plural category of the formatted number.
+func formatMeasure(d decimal.Decimal, unit, lang, width string, opts numberOptions) string {
+	sym := symbolsFor(lang)
+	number := sym.sign(d, opts, sym.formatDecimal(d, opts))
+	intPart, fracPart := plainDecimal(d, opts)
+	category := decimalPluralCategory(plural.Cardinal, lang, intPart, fracPart)
+	forms := lookupLocale(unitData, lang).unitForms(unit, width)
+	return strings.Replace(forms.pick(category), "{0}", number, 1)
+}
+
+// toDuration converts a template value to a duration: a time.Duration, a
+// duration string such as "2h30m", or a number of seconds.
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		if d, err := time.ParseDuration(strings.TrimSpace(v)); err == nil {
+			return d, nil
+		}
+	}
+	seconds, err := toDecimal(value)
+	if err != nil {
+		return 0, fmt.Errorf("%v or duration", err)
+	}
+	return time.Duration(seconds.Shift(9).IntPart()), nil
+}
+
+// formatDurationUnits formats value as a duration in days, hours, minutes and
+// seconds, e.g. "2 Std., 30 Min.", joined with the unit list pattern of lang.
+// Durations below a second are shown in milliseconds; otherwise the
+// duration is rounded to seconds and components that are zero are left out.
+func formatDurationUnits(value interface{}, lang string, options unitOptions) (string, error) {
+	d, err := toDuration(value)
+	if err != nil {
+		return "", err
+	}
+	numberOpts, err := parseNumberOptions(options.number, defaultNumberOptions)
+	if err != nil {
+		return "", err
+	}
+	sign := int64(1)
+	if d < 0 {
+		sign, d = -1, -d
+	}
+	if d < time.Second {
+		return formatMeasure(decimal.NewFromInt(sign*d.Milliseconds()), "millisecond", lang, options.width, numberOpts), nil
+	}
+
+	d = d.Round(time.Second)
+	var parts []string
+	for _, unit := range durationUnits {
+		count := int64(d / unit.length)
+		if count == 0 {
+			continue
+		}
+		d -= time.Duration(count) * unit.length
+		parts = append(parts, formatMeasure(decimal.NewFromInt(sign*count), unit.name, lang, options.width, numberOpts))
+		sign = 1
+	}
+	return joinList(parts, lookupLocale(listData, lang)[listUnit]), nil
+}
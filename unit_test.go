@@ -0,0 +1,150 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFormatUnit(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		unit     string
+		lang     string
+		opts     string
+		expected string
+	}{
+		{5, "kilometer", "en", "", "5 km"},
+		{5, "kilometer", "en", "narrow", "5km"},
+		{5, "kilometer", "de", "narrow", "5 km"},
+		{1, "kilometer", "en", "long", "1 kilometer"},
+		{5, "kilometer", "en", "long", "5 kilometers"},
+		{1.5, "kilometer", "en", "long", "1.5 kilometers"},
+		{1.5, "kilometer", "fr", "long", "1,5 kilomètre"},
+		{2.25, "kilogram", "de", "long max=1", "2,2 Kilogramm"},
+		{1, "hour", "de", "", "1 Std."},
+		{3, "hour", "de", "long", "3 Stunden"},
+		{1, "hour", "de", "long", "1 Stunde"},
+		{2, "year", "en", "", "2 yrs"},
+		{2, "week", "it", "long", "2 settimane"},
+		{1, "foot", "en", "long", "1 foot"},
+		{6, "foot", "en", "long", "6 feet"},
+		{3, "mile", "es", "long", "3 millas"},
+		{12000, "meter", "de", "long", "12.000 Meter"},
+		{3, "pound", "sv", "long", "3 pounds"},
+
+		// Unbundled locales fall back to English entirely, including the
+		// plural category, the digits and the list pattern
+		{1, "day", "ja", "long", "1 day"},
+		{1, "day", "ar", "long", "1 day"},
+		{1234, "meter", "ar", "long", "1,234 meters"},
+		{"1h30m", "duration", "zh", "long", "1 hour, 30 minutes"},
+
+		// Byte sizes
+		{500, "bytes", "en", "", "500 byte"},
+		{500, "bytes", "en", "long", "500 bytes"},
+		{1536, "bytes", "de", "", "1,5 KiB"},
+		{1536, "bytes", "de", "si", "1,5 kB"},
+		{1500000, "bytes", "en", "si", "1.5 MB"},
+		{1048575, "bytes", "en", "", "1 MiB"},
+		{"5368709120", "bytes", "en", "long", "5 gibibytes"},
+		{1536, "bytes", "fr", "", "1,5 Kio"},
+		{2048, "bytes", "fr", "long", "2 kibioctets"},
+		{1234567, "bytes", "en", "si max=2", "1.23 MB"},
+		{3, "gigabyte", "fr", "", "3 Go"},
+
+		// Durations
+		{"2h30m", "duration", "de", "", "2 Std., 30 Min."},
+		{90061, "duration", "en", "long", "1 day, 1 hour, 1 minute, 1 second"},
+		{90061 * time.Second, "duration", "de", "long", "1 Tag, 1 Stunde, 1 Minute und 1 Sekunde"},
+		{"3h", "duration", "fr", "long", "3 heures"},
+		{250 * time.Millisecond, "duration", "en", "", "250 ms"},
+		{-90 * time.Second, "duration", "en", "", "-1 min, 30 sec"},
+		{0, "duration", "en", "long", "0 milliseconds"},
+	}
+
+	for _, tt := range tests {
+		result, err := formatUnit(tt.value, tt.unit, tt.lang, tt.opts)
+		if err != nil {
+			t.Errorf("formatUnit(%v, %q, %q, %q): unexpected error: %v", tt.value, tt.unit, tt.lang, tt.opts, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("formatUnit(%v, %q, %q, %q): expected %q, got %q", tt.value, tt.unit, tt.lang, tt.opts, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatUnitErrors(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		unit  string
+		opts  string
+	}{
+		{5, "parsec", ""},
+		{5, "meter", "max=x"},
+		{"far", "meter", ""},
+		{"soon", "duration", ""},
+	}
+	for _, tt := range tests {
+		if _, err := formatUnit(tt.value, tt.unit, "en", tt.opts); err == nil {
+			t.Errorf("formatUnit(%v, %q, %q): expected error, got nil", tt.value, tt.unit, tt.opts)
+		}
+	}
+}
+
+func TestUnitPlaceholder(t *testing.T) {
+	i18n := &I18n{
+		translations: map[string]map[string]string{
+			"quota.used": {
+				"de": "{0, unit, bytes} von {1, unit, bytes si} belegt",
+				"en": "{0, unit, bytes long} of {1, unit, bytes si long} used",
+			},
+			"quota.broken": {
+				"en": "{0, unit} used",
+			},
+		},
+	}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+
+	args := []interface{}{1536, 2000000000}
+	if result := i18n.translate(nil, "quota.used", "de", args); result != "1,5 KiB von 2 GB belegt" {
+		t.Errorf("unexpected German result %q", result)
+	}
+	if result := i18n.translate(nil, "quota.used", "en", args); result != "1.5 kibibytes of 2 gigabytes used" {
+		t.Errorf("unexpected English result %q", result)
+	}
+
+	// Without a unit, the argument is used as is
+	if result := i18n.translate(nil, "quota.broken", "en", args); result != "1536 used" {
+		t.Errorf("unexpected result without unit %q", result)
+	}
+}
+
+func TestI18nUnitFunction(t *testing.T) {
+	unitFunc := (&I18n{}).CustomTemplateFunctions()["i18nUnit"].(func(interface{}, string, string, ...string) (string, error))
+
+	if result, err := unitFunc(1536, "bytes", "de"); err != nil || result != "1,5 KiB" {
+		t.Errorf("expected '1,5 KiB', got %q (%v)", result, err)
+	}
+	if result, err := unitFunc(42.195, "kilometer", "fr", "long", "max=1"); err != nil || result != "42,2 kilomètres" {
+		t.Errorf("expected '42,2 kilomètres', got %q (%v)", result, err)
+	}
+}
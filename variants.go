@@ -0,0 +1,101 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"golang.org/x/text/feature/plural"
+)
+
+// variantSelector returns the form of a translation with variants to use,
+// given the language the translation is taken from, e.g. the ordinal plural
+// category of a number in that language.
+type variantSelector func(lang string) string
+
+// parseDictionary converts a raw dictionary into translations and variants.
+// A translation is either a string or an object of forms such as the ordinal
+// forms {"one": "{0}st", "two": "{0}nd", "few": "{0}rd", "other": "{0}th"} or
+// the select forms {"male": "...", "female": "...", "other": "..."}, which
+// must have an "other" form. The "other" form is also used as the plain
+// translation, e.g. for i18nTranslate, bundles and the coverage report.
+func parseDictionary(raw map[string]map[string]json.RawMessage) (map[string]map[string]string, map[string]map[string]pluralForms, error) {
+	translations := make(map[string]map[string]string, len(raw))
+	variants := make(map[string]map[string]pluralForms)
+	for key, entry := range raw {
+		translations[key] = make(map[string]string, len(entry))
+		for lang, value := range entry {
+			var text string
+			if err := json.Unmarshal(value, &text); err == nil {
+				translations[key][lang] = text
+				continue
+			}
+			var forms pluralForms
+			if err := json.Unmarshal(value, &forms); err != nil {
+				return nil, nil, fmt.Errorf("translation of %q in %q must be a string or an object of strings", key, lang)
+			}
+			other, ok := forms["other"]
+			if !ok {
+				return nil, nil, fmt.Errorf("translation of %q in %q has no \"other\" form", key, lang)
+			}
+			translations[key][lang] = other
+			if variants[key] == nil {
+				variants[key] = make(map[string]pluralForms)
+			}
+			variants[key][lang] = forms
+		}
+	}
+	return translations, variants, nil
+}
+
+// ordinalSelector returns a variantSelector choosing the CLDR ordinal plural
+// category of the integer n, e.g. "two" for 22 in English.
+func ordinalSelector(n interface{}) (variantSelector, error) {
+	d, err := toDecimal(n)
+	if err != nil {
+		return nil, err
+	}
+	if !d.IsInteger() {
+		return nil, fmt.Errorf("ordinal %s is not an integer", d)
+	}
+	return func(lang string) string {
+		return pluralCategory(plural.Ordinal, lang, d.IntPart())
+	}, nil
+}
+
+// translateOrdinal translates key with the ordinal form for n, which is
+// interpolated as {0}, followed by args as {1}, {2}, etc. The template
+// context ctx may be nil, as for translateCtx.
+func (i *I18n) translateOrdinal(ctx *templates.TemplateContext, key, lang string, n interface{}, args []interface{}) (string, error) {
+	selector, err := ordinalSelector(n)
+	if err != nil {
+		return "", err
+	}
+	return i.translateCtx(ctx, key, lang, append([]interface{}{n}, args...), selector), nil
+}
+
+// translateSelect translates key with the form named by value, such as
+// "female", or the "other" form if the translation has no such form.
+// A nil value selects the "other" form. The template context ctx may be
+// nil, as for translateCtx.
+func (i *I18n) translateSelect(ctx *templates.TemplateContext, key, lang string, value interface{}, args []interface{}) string {
+	selected := "other"
+	if value != nil {
+		selected = fmt.Sprint(value)
+	}
+	return i.translateCtx(ctx, key, lang, args, func(string) string { return selected })
+}
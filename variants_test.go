@@ -0,0 +1,271 @@
+// Copyright 2025 Steffen Busch
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// 	http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
+	"go.uber.org/zap/zaptest"
+)
+
+const ordinalDict = `{
+	"rank.place": {
+		"en": {"one": "{0}st place", "two": "{0}nd place", "few": "{0}rd place", "other": "{0}th place"},
+		"de": "{0}. Platz",
+		"fr": {"one": "{0}er", "other": "{0}e"}
+	},
+	"rank.finished": {
+		"en": {"one": "{1} finished {0}st", "two": "{1} finished {0}nd", "few": "{1} finished {0}rd", "other": "{1} finished {0}th"},
+		"de": "{1} wurde {0, number}."
+	}
+}`
+
+func newTestVariantI18n(t *testing.T, dict string) *I18n {
+	t.Helper()
+	i18n := &I18n{DictFile: createTestDictFile(t, dict)}
+	i18n.mu = new(sync.RWMutex)
+	i18n.logger = zaptest.NewLogger(t)
+	var stubCaddyCtx caddy.Context
+	if err := i18n.Provision(stubCaddyCtx); err != nil {
+		t.Fatalf("Provision failed: %v", err)
+	}
+	t.Cleanup(func() { i18n.Cleanup() })
+	return i18n
+}
+
+func TestTranslateOrdinal(t *testing.T) {
+	i18n := newTestVariantI18n(t, ordinalDict)
+
+	tests := []struct {
+		n        interface{}
+		lang     string
+		expected string
+	}{
+		{1, "en", "1st place"},
+		{2, "en", "2nd place"},
+		{3, "en", "3rd place"},
+		{4, "en", "4th place"},
+		{11, "en", "11th place"},
+		{12, "en", "12th place"},
+		{13, "en", "13th place"},
+		{21, "en", "21st place"},
+		{22, "en", "22nd place"},
+		{101, "en", "101st place"},
+		{111, "en", "111th place"},
+		{"3", "en", "3rd place"},
+		{1, "de", "1. Platz"},
+		{3, "de", "3. Platz"},
+		{1, "fr", "1er"},
+		{3, "fr", "3e"},
+
+		// The fallback to English uses the English ordinal rules
+		{2, "sv", "2nd place"},
+		{23, "sv", "23rd place"},
+	}
+
+	for _, tt := range tests {
+		result, err := i18n.translateOrdinal(nil, "rank.place", tt.lang, tt.n, nil)
+		if err != nil {
+			t.Errorf("translateOrdinal(%v, %q): unexpected error: %v", tt.n, tt.lang, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("translateOrdinal(%v, %q): expected %q, got %q", tt.n, tt.lang, tt.expected, result)
+		}
+	}
+}
+
+func TestTranslateOrdinalWithArgs(t *testing.T) {
+	i18n := newTestVariantI18n(t, ordinalDict)
+
+	if result, _ := i18n.translateOrdinal(nil, "rank.finished", "en", 2, []interface{}{"Anna"}); result != "Anna finished 2nd" {
+		t.Errorf("expected 'Anna finished 2nd', got %q", result)
+	}
+	if result, _ := i18n.translateOrdinal(nil, "rank.finished", "de", 1000, []interface{}{"Anna"}); result != "Anna wurde 1.000." {
+		t.Errorf("expected 'Anna wurde 1.000.', got %q", result)
+	}
+}
+
+func TestTranslateOrdinalErrors(t *testing.T) {
+	i18n := newTestVariantI18n(t, ordinalDict)
+
+	for _, n := range []interface{}{"first", 1.5} {
+		if _, err := i18n.translateOrdinal(nil, "rank.place", "en", n, nil); err == nil {
+			t.Errorf("expected error for ordinal %v, got nil", n)
+		}
+	}
+
+	// Missing keys behave as in i18nTranslate
+	if result, err := i18n.translateOrdinal(nil, "rank.unknown", "en", 1, nil); err != nil || result != "rank.unknown" {
+		t.Errorf("expected key as fallback, got %q (%v)", result, err)
+	}
+}
+
+func TestVariantEntryPlainLookup(t *testing.T) {
+	i18n := newTestVariantI18n(t, ordinalDict)
+
+	// Lookups without a selector use the "other" form
+	if result := i18n.translate(nil, "rank.place", "en", []interface{}{5}); result != "5th place" {
+		t.Errorf("expected '5th place', got %q", result)
+	}
+	if i18n.translations["rank.place"]["fr"] != "{0}e" {
+		t.Errorf("expected the other form in translations, got %q", i18n.translations["rank.place"]["fr"])
+	}
+	if i18n.langCounts["en"] != 2 {
+		t.Errorf("expected 2 English keys, got %d", i18n.langCounts["en"])
+	}
+
+	bundle, err := i18n.bundleJSON("en", []string{"rank.place"})
+	if err != nil || !strings.Contains(bundle, `"{0}th place"`) {
+		t.Errorf("expected the other form in the bundle, got %s (%v)", bundle, err)
+	}
+}
+
+func TestParseDictionaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dict string
+	}{
+		{"no other form", `{"rank.place": {"en": {"one": "{0}st"}}}`},
+		{"number", `{"count": {"en": 5}}`},
+		{"nested object", `{"rank.place": {"en": {"other": {"x": "y"}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i18n := &I18n{DictFile: createTestDictFile(t, tt.dict)}
+			i18n.logger = zaptest.NewLogger(t)
+			var stubCaddyCtx caddy.Context
+			if err := i18n.Provision(stubCaddyCtx); err == nil {
+				t.Fatal("expected error for invalid dictionary")
+			}
+		})
+	}
+}
+
+func TestI18nOrdinalFunction(t *testing.T) {
+	i18n := newTestVariantI18n(t, ordinalDict)
+	ordinalFunc := i18n.CustomTemplateFunctions()["i18nOrdinal"].(func(*templates.TemplateContext, string, string, interface{}, ...interface{}) (string, error))
+
+	header := make(http.Header)
+	ctx := &templates.TemplateContext{
+		Req:        httptest.NewRequest(http.MethodGet, "/results", nil),
+		RespHeader: templates.WrappedHeader{Header: header},
+	}
+
+	if result, err := ordinalFunc(ctx, "rank.place", "en", 22); err != nil || result != "22nd place" {
+		t.Errorf("expected '22nd place', got %q (%v)", result, err)
+	}
+	if result, err := ordinalFunc(ctx, "rank.finished", "fr", 3, "Ben"); err != nil || result != "Ben finished 3rd" {
+		t.Errorf("expected 'Ben finished 3rd', got %q (%v)", result, err)
+	}
+	// The French translation of rank.finished fell back to English
+	if got := header.Get("Content-Language"); got != "en" {
+		t.Errorf("expected Content-Language 'en', got %q", got)
+	}
+	entries, _ := i18n.missing.report()
+	if len(entries) != 1 || entries[0].Key != "rank.finished" || !slices.Equal(entries[0].Paths, []string{"/results"}) {
+		t.Errorf("expected the missing translation to be recorded with its path, got %+v", entries)
+	}
+}
+
+const selectDict = `{
+	"notify.shared": {
+		"de": {"male": "{0} hat dich zu seinem Team hinzugefügt", "female": "{0} hat dich zu ihrem Team hinzugefügt", "other": "{0} hat dich zum Team hinzugefügt"},
+		"fr": {"male": "{0} est connecté", "female": "{0} est connectée", "other": "{0} est connecté·e"},
+		"en": {"other": "{0} added you to their team"}
+	},
+	"notify.welcome": {
+		"de": {"female": "Willkommen, liebe {0}", "other": "Willkommen, {0}"},
+		"en": "Welcome, {0}"
+	}
+}`
+
+func TestTranslateSelect(t *testing.T) {
+	i18n := newTestVariantI18n(t, selectDict)
+
+	tests := []struct {
+		key      string
+		lang     string
+		value    interface{}
+		expected string
+	}{
+		{"notify.shared", "de", "male", "Anna hat dich zu seinem Team hinzugefügt"},
+		{"notify.shared", "de", "female", "Anna hat dich zu ihrem Team hinzugefügt"},
+		{"notify.shared", "de", "other", "Anna hat dich zum Team hinzugefügt"},
+		{"notify.shared", "de", "diverse", "Anna hat dich zum Team hinzugefügt"},
+		{"notify.shared", "de", "", "Anna hat dich zum Team hinzugefügt"},
+		{"notify.shared", "de", nil, "Anna hat dich zum Team hinzugefügt"},
+		{"notify.shared", "fr", "female", "Anna est connectée"},
+		{"notify.shared", "en", "female", "Anna added you to their team"},
+		{"notify.welcome", "de", "female", "Willkommen, liebe Anna"},
+		{"notify.welcome", "de", "male", "Willkommen, Anna"},
+
+		// String translations ignore the selector
+		{"notify.welcome", "en", "female", "Welcome, Anna"},
+
+		// The fallback to English selects among the English forms
+		{"notify.shared", "sv", "female", "Anna added you to their team"},
+		{"notify.welcome", "fr", "female", "Welcome, Anna"},
+
+		// Missing keys behave as in i18nTranslate
+		{"notify.unknown", "de", "female", "notify.unknown"},
+	}
+
+	for _, tt := range tests {
+		result := i18n.translateSelect(nil, tt.key, tt.lang, tt.value, []interface{}{"Anna"})
+		if result != tt.expected {
+			t.Errorf("translateSelect(%q, %q, %v): expected %q, got %q", tt.key, tt.lang, tt.value, tt.expected, result)
+		}
+	}
+}
+
+func TestI18nSelectFunction(t *testing.T) {
+	i18n := newTestVariantI18n(t, selectDict)
+	selectFunc := i18n.CustomTemplateFunctions()["i18nSelect"].(func(*templates.TemplateContext, string, string, interface{}, ...interface{}) (string, error))
+
+	header := make(http.Header)
+	ctx := &templates.TemplateContext{
+		Req:        httptest.NewRequest(http.MethodGet, "/team?i18n_debug=key", nil),
+		RespHeader: templates.WrappedHeader{Header: header},
+	}
+
+	if result, err := selectFunc(ctx, "notify.shared", "de", "female", "Anna"); err != nil || result != "Anna hat dich zu ihrem Team hinzugefügt" {
+		t.Errorf("expected the female form, got %q (%v)", result, err)
+	}
+	if got := header.Get("Content-Language"); got != "de" {
+		t.Errorf("expected Content-Language 'de', got %q", got)
+	}
+
+	// The request enables debug keys
+	i18n.DebugKeys = &DebugKeysConfig{Mode: debugModeKey}
+	if result, _ := selectFunc(ctx, "notify.shared", "de", "female", "Anna"); result != "notify.shared" {
+		t.Errorf("expected the debug key, got %q", result)
+	}
+	i18n.DebugKeys = nil
+
+	// Plain lookups use the "other" form
+	translateFunc := i18n.CustomTemplateFunctions()["i18nTranslate"].(func(string, string, ...interface{}) (string, error))
+	if result, _ := translateFunc("notify.shared", "de", "Anna"); result != "Anna hat dich zum Team hinzugefügt" {
+		t.Errorf("expected the other form, got %q", result)
+	}
+}